@@ -0,0 +1,305 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"terraform-provider-arcane/internal/sdkclient"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &ContainerLogsDataSource{}
+
+type ContainerLogsDataSource struct{ client *sdkclient.Client }
+
+func NewContainerLogsDataSource() datasource.DataSource { return &ContainerLogsDataSource{} }
+
+const (
+	defaultLogsTail     = 100
+	defaultLogsMaxBytes = 64 * 1024
+)
+
+func (d *ContainerLogsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_container_logs"
+}
+
+func (d *ContainerLogsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Recent log lines of a container, or of every service container of a project",
+		Attributes: map[string]schema.Attribute{
+			"environment_id": schema.StringAttribute{Required: true, Description: "Environment ID"},
+			"container_id": schema.StringAttribute{
+				Optional: true, Description: "Container ID or name. Exactly one of container_id and project_id must be set.",
+				Validators: []validator.String{stringvalidator.ExactlyOneOf(path.MatchRoot("project_id"))},
+			},
+			"project_id": schema.StringAttribute{Optional: true, Description: "Project ID; aggregates the logs of its service containers"},
+			"services": schema.ListAttribute{
+				Optional: true, ElementType: types.StringType,
+				Description: "Project services to read; all services when unset",
+				Validators:  []validator.List{listvalidator.AlsoRequires(path.MatchRoot("project_id"))},
+			},
+			"tail": schema.Int64Attribute{
+				Optional: true, Description: fmt.Sprintf("Lines to read per container, 0 for all (default %d)", defaultLogsTail),
+				Validators: []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"since": schema.StringAttribute{
+				Optional: true, Description: "Only lines since this RFC3339 time, or within this duration before now (e.g. 15m)",
+				Validators: []validator.String{logsSinceValidator{}},
+			},
+			"timestamps": schema.BoolAttribute{Optional: true, Description: "Include timestamps in lines and logs"},
+			"stdout":     schema.BoolAttribute{Optional: true, Description: "Include stdout (default true)"},
+			"stderr":     schema.BoolAttribute{Optional: true, Description: "Include stderr (default true)"},
+			"max_bytes": schema.Int64Attribute{
+				Optional: true, Description: fmt.Sprintf("Cap on the size of the returned messages; the oldest lines are dropped first (default %d)", defaultLogsMaxBytes),
+				Validators: []validator.Int64{int64validator.Between(1, 4*1024*1024)},
+			},
+
+			// Computed
+			"lines": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Log lines, oldest first",
+				NestedObject: schema.NestedAttributeObject{Attributes: map[string]schema.Attribute{
+					"service":      schema.StringAttribute{Computed: true, Description: "Project service; empty for container_id"},
+					"container_id": schema.StringAttribute{Computed: true, Description: "Container that wrote the line"},
+					"stream":       schema.StringAttribute{Computed: true, Description: "stdout or stderr"},
+					"timestamp":    schema.StringAttribute{Computed: true, Description: "RFC3339 time; empty unless timestamps is set"},
+					"message":      schema.StringAttribute{Computed: true, Description: "Line content"},
+				}},
+			},
+			"logs":      schema.StringAttribute{Computed: true, Description: "The lines joined by newlines; project lines are prefixed with the service name"},
+			"truncated": schema.BoolAttribute{Computed: true, Description: "Whether lines were dropped to stay under max_bytes"},
+		},
+	}
+}
+
+func (d *ContainerLogsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = setProviderClient(req, resp)
+}
+
+var containerLogLineType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"service":      types.StringType,
+	"container_id": types.StringType,
+	"stream":       types.StringType,
+	"timestamp":    types.StringType,
+	"message":      types.StringType,
+}}
+
+type containerLogsModel struct {
+	EnvironmentID types.String `tfsdk:"environment_id"`
+	ContainerID   types.String `tfsdk:"container_id"`
+	ProjectID     types.String `tfsdk:"project_id"`
+	Services      types.List   `tfsdk:"services"`
+	Tail          types.Int64  `tfsdk:"tail"`
+	Since         types.String `tfsdk:"since"`
+	Timestamps    types.Bool   `tfsdk:"timestamps"`
+	Stdout        types.Bool   `tfsdk:"stdout"`
+	Stderr        types.Bool   `tfsdk:"stderr"`
+	MaxBytes      types.Int64  `tfsdk:"max_bytes"`
+	Lines         types.List   `tfsdk:"lines"`
+	Logs          types.String `tfsdk:"logs"`
+	Truncated     types.Bool   `tfsdk:"truncated"`
+}
+
+type containerLogLineModel struct {
+	Service     types.String `tfsdk:"service"`
+	ContainerID types.String `tfsdk:"container_id"`
+	Stream      types.String `tfsdk:"stream"`
+	Timestamp   types.String `tfsdk:"timestamp"`
+	Message     types.String `tfsdk:"message"`
+}
+
+// serviceLogLine is a log line tagged with the service that wrote it.
+type serviceLogLine struct {
+	service, containerID string
+	sdkclient.ContainerLogLine
+}
+
+func (d *ContainerLogsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state containerLogsModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	envID := state.EnvironmentID.ValueString()
+	opts := sdkclient.ContainerLogsOptions{
+		Tail:   defaultLogsTail,
+		Stdout: state.Stdout.IsNull() || state.Stdout.ValueBool(),
+		Stderr: state.Stderr.IsNull() || state.Stderr.ValueBool(),
+		// Timestamps order the lines of several containers; they are dropped again unless asked for.
+		Timestamps: state.Timestamps.ValueBool() || !state.ProjectID.IsNull(),
+	}
+	if !state.Tail.IsNull() {
+		opts.Tail = state.Tail.ValueInt64()
+	}
+	if !state.Since.IsNull() {
+		opts.Since = logsSince(state.Since.ValueString(), time.Now())
+	}
+
+	var lines []serviceLogLine
+	if !state.ContainerID.IsNull() {
+		id := state.ContainerID.ValueString()
+		out, err := d.client.GetContainerLogs(ctx, envID, id, opts)
+		if err != nil {
+			if strings.Contains(strings.ToLower(err.Error()), "404") {
+				resp.Diagnostics.AddError("container not found", "No container with id: "+id)
+				return
+			}
+			resp.Diagnostics.AddError("failed to read container logs", err.Error())
+			return
+		}
+		for _, l := range out {
+			lines = append(lines, serviceLogLine{containerID: id, ContainerLogLine: l})
+		}
+	} else {
+		projID := state.ProjectID.ValueString()
+		project, err := d.client.GetProject(ctx, envID, projID)
+		if err != nil {
+			if strings.Contains(strings.ToLower(err.Error()), "404") {
+				resp.Diagnostics.AddError("project not found", "No project with id: "+projID)
+				return
+			}
+			resp.Diagnostics.AddError("failed to read project", err.Error())
+			return
+		}
+		wanted, found := map[string]bool{}, map[string]bool{}
+		for _, s := range listToStrings(ctx, state.Services) {
+			wanted[s] = true
+		}
+		for _, svc := range project.RuntimeServices {
+			if len(wanted) > 0 && !wanted[svc.Name] {
+				continue
+			}
+			found[svc.Name] = true
+			for _, id := range serviceContainerIDs(svc) {
+				out, err := d.client.GetContainerLogs(ctx, envID, id, opts)
+				if err != nil {
+					// A replica removed since the project was read has no logs to show.
+					if strings.Contains(strings.ToLower(err.Error()), "404") {
+						continue
+					}
+					resp.Diagnostics.AddError("failed to read container logs", fmt.Sprintf("service %s: %s", svc.Name, err))
+					return
+				}
+				for _, l := range out {
+					lines = append(lines, serviceLogLine{service: svc.Name, containerID: id, ContainerLogLine: l})
+				}
+			}
+		}
+		for s := range wanted {
+			if found[s] {
+				continue
+			}
+			resp.Diagnostics.AddWarning("service not running", fmt.Sprintf("project %s has no running service %q", projID, s))
+		}
+		sort.SliceStable(lines, func(i, j int) bool { return lines[i].Timestamp < lines[j].Timestamp })
+	}
+
+	maxBytes := int64(defaultLogsMaxBytes)
+	if !state.MaxBytes.IsNull() {
+		maxBytes = state.MaxBytes.ValueInt64()
+	}
+	lines, truncated := capLogLines(lines, maxBytes)
+
+	models := make([]containerLogLineModel, 0, len(lines))
+	text := make([]string, 0, len(lines))
+	for _, l := range lines {
+		msg := strings.TrimRight(l.Message, "\r\n")
+		ts := ""
+		if state.Timestamps.ValueBool() {
+			ts = l.Timestamp
+		}
+		models = append(models, containerLogLineModel{
+			Service:     types.StringValue(l.service),
+			ContainerID: types.StringValue(l.containerID),
+			Stream:      types.StringValue(l.Stream),
+			Timestamp:   types.StringValue(ts),
+			Message:     types.StringValue(msg),
+		})
+		prefix := ""
+		if l.service != "" {
+			prefix = l.service + " | "
+		}
+		if ts != "" {
+			prefix += ts + " "
+		}
+		text = append(text, prefix+msg)
+	}
+	list, diags := types.ListValueFrom(ctx, containerLogLineType, models)
+	resp.Diagnostics.Append(diags...)
+	state.Lines = list
+	state.Logs = types.StringValue(strings.Join(text, "\n"))
+	state.Truncated = types.BoolValue(truncated)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// serviceContainerIDs lists the replicas of a service, falling back to the
+// single container ID older servers report.
+func serviceContainerIDs(svc sdkclient.ProjectRuntimeService) []string {
+	if len(svc.ContainerIDs) > 0 {
+		return svc.ContainerIDs
+	}
+	if svc.ContainerID != "" {
+		return []string{svc.ContainerID}
+	}
+	return nil
+}
+
+// capLogLines keeps the newest lines whose messages fit in maxBytes.
+func capLogLines(lines []serviceLogLine, maxBytes int64) ([]serviceLogLine, bool) {
+	var size int64
+	for i := len(lines) - 1; i >= 0; i-- {
+		size += int64(len(lines[i].Message))
+		if size > maxBytes {
+			return lines[i+1:], true
+		}
+	}
+	return lines, false
+}
+
+// logsSince turns a since value validated by logsSinceValidator into the
+// server form: RFC3339 times pass through, durations become a Unix timestamp.
+func logsSince(v string, now time.Time) string {
+	if d, err := time.ParseDuration(v); err == nil {
+		return fmt.Sprint(now.Add(-d).Unix())
+	}
+	return v
+}
+
+// logsSinceValidator checks a string is an RFC3339 time or a positive duration.
+type logsSinceValidator struct{}
+
+var _ validator.String = logsSinceValidator{}
+
+func (logsSinceValidator) Description(_ context.Context) string {
+	return "value must be an RFC3339 time or a positive duration such as 15m"
+}
+
+func (v logsSinceValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v logsSinceValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	s := req.ConfigValue.ValueString()
+	if d, err := time.ParseDuration(s); err == nil && d > 0 {
+		return
+	}
+	if _, err := time.Parse(time.RFC3339, s); err == nil {
+		return
+	}
+	resp.Diagnostics.AddAttributeError(req.Path, "invalid since", v.Description(ctx)+", got "+req.ConfigValue.String())
+}
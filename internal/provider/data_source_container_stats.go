@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"terraform-provider-arcane/internal/sdkclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &ContainerStatsDataSource{}
+
+type ContainerStatsDataSource struct{ client *sdkclient.Client }
+
+func NewContainerStatsDataSource() datasource.DataSource { return &ContainerStatsDataSource{} }
+
+func (d *ContainerStatsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_container_stats"
+}
+
+func (d *ContainerStatsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attrs := containerStatsAttributes()
+	attrs["environment_id"] = schema.StringAttribute{Required: true, Description: "Environment ID"}
+	attrs["container_id"] = schema.StringAttribute{Required: true, Description: "Container ID or name"}
+	attrs["name"] = schema.StringAttribute{Computed: true, Description: "Container name"}
+	resp.Schema = schema.Schema{
+		Description: "One-shot resource usage sample of a container",
+		Attributes:  attrs,
+	}
+}
+
+func (d *ContainerStatsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = setProviderClient(req, resp)
+}
+
+// containerStatsAttributes are the usage figures shared by the container and
+// project stats data sources.
+func containerStatsAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"read_at":           schema.StringAttribute{Computed: true, Description: "RFC3339 time of the sample"},
+		"cpu_percent":       schema.Float64Attribute{Computed: true, Description: "CPU usage over the sampling interval; 100 is one full CPU"},
+		"online_cpus":       schema.Int64Attribute{Computed: true, Description: "CPUs available to the container"},
+		"memory_usage":      schema.Int64Attribute{Computed: true, Description: "Memory in use in bytes, excluding page cache"},
+		"memory_limit":      schema.Int64Attribute{Computed: true, Description: "Memory limit in bytes; the host memory when unlimited"},
+		"memory_percent":    schema.Float64Attribute{Computed: true, Description: "memory_usage as a percentage of memory_limit"},
+		"network_rx_bytes":  schema.Int64Attribute{Computed: true, Description: "Bytes received on all interfaces since start"},
+		"network_tx_bytes":  schema.Int64Attribute{Computed: true, Description: "Bytes sent on all interfaces since start"},
+		"block_read_bytes":  schema.Int64Attribute{Computed: true, Description: "Bytes read from block devices since start"},
+		"block_write_bytes": schema.Int64Attribute{Computed: true, Description: "Bytes written to block devices since start"},
+		"pids":              schema.Int64Attribute{Computed: true, Description: "Number of processes"},
+	}
+}
+
+var containerStatsAttrTypes = map[string]attr.Type{
+	"read_at":           types.StringType,
+	"cpu_percent":       types.Float64Type,
+	"online_cpus":       types.Int64Type,
+	"memory_usage":      types.Int64Type,
+	"memory_limit":      types.Int64Type,
+	"memory_percent":    types.Float64Type,
+	"network_rx_bytes":  types.Int64Type,
+	"network_tx_bytes":  types.Int64Type,
+	"block_read_bytes":  types.Int64Type,
+	"block_write_bytes": types.Int64Type,
+	"pids":              types.Int64Type,
+}
+
+type containerStatsValues struct {
+	ReadAt          types.String  `tfsdk:"read_at"`
+	CPUPercent      types.Float64 `tfsdk:"cpu_percent"`
+	OnlineCPUs      types.Int64   `tfsdk:"online_cpus"`
+	MemoryUsage     types.Int64   `tfsdk:"memory_usage"`
+	MemoryLimit     types.Int64   `tfsdk:"memory_limit"`
+	MemoryPercent   types.Float64 `tfsdk:"memory_percent"`
+	NetworkRxBytes  types.Int64   `tfsdk:"network_rx_bytes"`
+	NetworkTxBytes  types.Int64   `tfsdk:"network_tx_bytes"`
+	BlockReadBytes  types.Int64   `tfsdk:"block_read_bytes"`
+	BlockWriteBytes types.Int64   `tfsdk:"block_write_bytes"`
+	Pids            types.Int64   `tfsdk:"pids"`
+}
+
+type containerStatsModel struct {
+	EnvironmentID types.String `tfsdk:"environment_id"`
+	ContainerID   types.String `tfsdk:"container_id"`
+	Name          types.String `tfsdk:"name"`
+	containerStatsValues
+}
+
+func (d *ContainerStatsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state containerStatsModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	id := state.ContainerID.ValueString()
+	stats, err := d.client.GetContainerStats(ctx, state.EnvironmentID.ValueString(), id)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "404") {
+			resp.Diagnostics.AddError("container not found", "No container with id: "+id)
+			return
+		}
+		resp.Diagnostics.AddError("failed to read container stats", err.Error())
+		return
+	}
+	state.Name = types.StringValue(strings.TrimPrefix(stats.Name, "/"))
+	state.containerStatsValues = statsValues(stats)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// statsValues derives usage figures the way the docker CLI does.
+func statsValues(s *sdkclient.ContainerStats) containerStatsValues {
+	cpus := uint64(s.CPUStats.OnlineCPUs)
+	if cpus == 0 {
+		cpus = uint64(len(s.CPUStats.CPUUsage.PercpuUsage))
+	}
+	var cpuPercent float64
+	cpuDelta := float64(s.CPUStats.CPUUsage.TotalUsage) - float64(s.PreCPUStats.CPUUsage.TotalUsage)
+	sysDelta := float64(s.CPUStats.SystemUsage) - float64(s.PreCPUStats.SystemUsage)
+	if cpuDelta > 0 && sysDelta > 0 {
+		cpuPercent = cpuDelta / sysDelta * float64(cpus) * 100
+	}
+
+	// Page cache can be reclaimed, so it does not count as usage: cgroup v2
+	// reports it as inactive_file, cgroup v1 as total_inactive_file or cache.
+	mem := s.MemoryStats.Usage
+	for _, k := range []string{"inactive_file", "total_inactive_file", "cache"} {
+		if v, ok := s.MemoryStats.Stats[k]; ok {
+			if v < mem {
+				mem -= v
+			}
+			break
+		}
+	}
+	var memPercent float64
+	if s.MemoryStats.Limit > 0 {
+		memPercent = float64(mem) / float64(s.MemoryStats.Limit) * 100
+	}
+
+	var rx, tx uint64
+	for _, n := range s.Networks {
+		rx += n.RxBytes
+		tx += n.TxBytes
+	}
+	var read, write uint64
+	for _, e := range s.BlkioStats.IoServiceBytesRecursive {
+		switch strings.ToLower(e.Op) {
+		case "read":
+			read += e.Value
+		case "write":
+			write += e.Value
+		}
+	}
+
+	return containerStatsValues{
+		ReadAt:          types.StringValue(s.Read),
+		CPUPercent:      types.Float64Value(cpuPercent),
+		OnlineCPUs:      types.Int64Value(int64(cpus)),
+		MemoryUsage:     types.Int64Value(int64(mem)),
+		MemoryLimit:     types.Int64Value(int64(s.MemoryStats.Limit)),
+		MemoryPercent:   types.Float64Value(memPercent),
+		NetworkRxBytes:  types.Int64Value(int64(rx)),
+		NetworkTxBytes:  types.Int64Value(int64(tx)),
+		BlockReadBytes:  types.Int64Value(int64(read)),
+		BlockWriteBytes: types.Int64Value(int64(write)),
+		Pids:            types.Int64Value(int64(s.PidsStats.Current)),
+	}
+}
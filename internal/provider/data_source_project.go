@@ -65,6 +65,7 @@ func (d *ProjectDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 				Computed:    true,
 				Description: "Number of running services",
 			},
+			"services": projectServicesDataSourceAttribute(),
 			"created_at": schema.StringAttribute{
 				Computed:    true,
 				Description: "Creation timestamp",
@@ -99,6 +100,7 @@ type projectDataSourceModel struct {
 	Status        types.String `tfsdk:"status"`
 	ServiceCount  types.Int64  `tfsdk:"service_count"`
 	RunningCount  types.Int64  `tfsdk:"running_count"`
+	Services      types.List   `tfsdk:"services"`
 	CreatedAt     types.String `tfsdk:"created_at"`
 	UpdatedAt     types.String `tfsdk:"updated_at"`
 }
@@ -132,6 +134,10 @@ func (d *ProjectDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		UpdatedAt:     types.StringValue(project.UpdatedAt),
 	}
 
+	services, diags := projectServicesValue(ctx, project.RuntimeServices)
+	resp.Diagnostics.Append(diags...)
+	state.Services = services
+
 	if project.ComposeContent != nil {
 		state.Compose = types.StringValue(*project.ComposeContent)
 	} else {
@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"terraform-provider-arcane/internal/sdkclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &ProjectRevisionsDataSource{}
+
+type ProjectRevisionsDataSource struct{ client *sdkclient.Client }
+
+func NewProjectRevisionsDataSource() datasource.DataSource { return &ProjectRevisionsDataSource{} }
+
+func (d *ProjectRevisionsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_revisions"
+}
+
+func (d *ProjectRevisionsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Deployment history of an arcane_project",
+		Attributes: map[string]schema.Attribute{
+			"environment_id": schema.StringAttribute{Required: true, Description: "Environment ID"},
+			"project_id":     schema.StringAttribute{Required: true, Description: "Project ID"},
+			"revisions": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Deploy attempts, oldest first.",
+				NestedObject: schema.NestedAttributeObject{Attributes: map[string]schema.Attribute{
+					"revision":     schema.Int64Attribute{Computed: true, Description: "Revision number"},
+					"deployed_at":  schema.StringAttribute{Computed: true, Description: "RFC3339 deploy time"},
+					"compose_hash": schema.StringAttribute{Computed: true, Description: "SHA256 of the deployed compose document"},
+					"env_hash":     schema.StringAttribute{Computed: true, Description: "SHA256 of the deployed .env content; empty when there was none"},
+					"outcome":      schema.StringAttribute{Computed: true, Description: "deployed, unhealthy, failed or rolled_back"},
+					"message":      schema.StringAttribute{Computed: true, Description: "Error detail for unsuccessful deploys"},
+				}},
+			},
+		},
+	}
+}
+
+func (d *ProjectRevisionsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = setProviderClient(req, resp)
+}
+
+var projectRevisionType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"revision":     types.Int64Type,
+	"deployed_at":  types.StringType,
+	"compose_hash": types.StringType,
+	"env_hash":     types.StringType,
+	"outcome":      types.StringType,
+	"message":      types.StringType,
+}}
+
+type projectRevisionsModel struct {
+	EnvironmentID types.String `tfsdk:"environment_id"`
+	ProjectID     types.String `tfsdk:"project_id"`
+	Revisions     types.List   `tfsdk:"revisions"`
+}
+
+type projectRevisionModel struct {
+	Revision    types.Int64  `tfsdk:"revision"`
+	DeployedAt  types.String `tfsdk:"deployed_at"`
+	ComposeHash types.String `tfsdk:"compose_hash"`
+	EnvHash     types.String `tfsdk:"env_hash"`
+	Outcome     types.String `tfsdk:"outcome"`
+	Message     types.String `tfsdk:"message"`
+}
+
+func (d *ProjectRevisionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state projectRevisionsModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	envID, projID := state.EnvironmentID.ValueString(), state.ProjectID.ValueString()
+
+	var revisions []projectRevisionModel
+	events, err := d.client.ListEnvironmentEvents(ctx, envID)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to read project event history", err.Error())
+		return
+	}
+	for _, e := range events {
+		if e.Type != projectDeployEvent || e.ResourceID != projID {
+			continue
+		}
+		revisions = append(revisions, projectRevisionModel{
+			Revision:    types.Int64Value(metadataInt(e.Metadata["revision"])),
+			DeployedAt:  types.StringValue(metadataString(e.Metadata, "deployedAt", e.Timestamp)),
+			ComposeHash: types.StringValue(metadataString(e.Metadata, "composeHash", "")),
+			EnvHash:     types.StringValue(metadataString(e.Metadata, "envHash", "")),
+			Outcome:     types.StringValue(metadataString(e.Metadata, "outcome", "")),
+			Message:     types.StringValue(e.Description),
+		})
+	}
+
+	sort.SliceStable(revisions, func(i, j int) bool {
+		if revisions[i].Revision.ValueInt64() != revisions[j].Revision.ValueInt64() {
+			return revisions[i].Revision.ValueInt64() < revisions[j].Revision.ValueInt64()
+		}
+		return revisions[i].DeployedAt.ValueString() < revisions[j].DeployedAt.ValueString()
+	})
+	list, diags := types.ListValueFrom(ctx, projectRevisionType, nonNilRevisions(revisions))
+	resp.Diagnostics.Append(diags...)
+	state.Revisions = list
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func nonNilRevisions(r []projectRevisionModel) []projectRevisionModel {
+	if r == nil {
+		return []projectRevisionModel{}
+	}
+	return r
+}
+
+// metadataInt reads a number from decoded JSON event metadata.
+func metadataInt(v any) int64 {
+	switch n := v.(type) {
+	case float64:
+		return int64(n)
+	case string:
+		i, _ := strconv.ParseInt(n, 10, 64)
+		return i
+	}
+	return 0
+}
+
+func metadataString(m map[string]any, key, fallback string) string {
+	if v, ok := m[key]; ok && v != nil {
+		return fmt.Sprint(v)
+	}
+	return fallback
+}
@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"terraform-provider-arcane/internal/sdkclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &ProjectStatsDataSource{}
+
+// projectStatsConcurrency caps the container stats requests in flight at once.
+const projectStatsConcurrency = 4
+
+type ProjectStatsDataSource struct{ client *sdkclient.Client }
+
+func NewProjectStatsDataSource() datasource.DataSource { return &ProjectStatsDataSource{} }
+
+func (d *ProjectStatsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_stats"
+}
+
+func (d *ProjectStatsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	containerAttrs := containerStatsAttributes()
+	containerAttrs["service"] = schema.StringAttribute{Computed: true, Description: "Compose service"}
+	containerAttrs["container_id"] = schema.StringAttribute{Computed: true, Description: "Container ID"}
+	containerAttrs["name"] = schema.StringAttribute{Computed: true, Description: "Container name"}
+	resp.Schema = schema.Schema{
+		Description: "One-shot resource usage sample of every service container of a project",
+		Attributes: map[string]schema.Attribute{
+			"environment_id": schema.StringAttribute{Required: true, Description: "Environment ID"},
+			"project_id":     schema.StringAttribute{Required: true, Description: "Project ID"},
+			"services": schema.ListAttribute{
+				Optional: true, ElementType: types.StringType,
+				Description: "Services to sample; all services when unset",
+			},
+
+			// Computed
+			"containers": schema.ListNestedAttribute{
+				Computed:     true,
+				Description:  "One sample per running container, ordered by service",
+				NestedObject: schema.NestedAttributeObject{Attributes: containerAttrs},
+			},
+			"cpu_percent":       schema.Float64Attribute{Computed: true, Description: "Sum of the containers' cpu_percent"},
+			"memory_usage":      schema.Int64Attribute{Computed: true, Description: "Sum of the containers' memory_usage in bytes"},
+			"network_rx_bytes":  schema.Int64Attribute{Computed: true, Description: "Sum of the containers' network_rx_bytes"},
+			"network_tx_bytes":  schema.Int64Attribute{Computed: true, Description: "Sum of the containers' network_tx_bytes"},
+			"block_read_bytes":  schema.Int64Attribute{Computed: true, Description: "Sum of the containers' block_read_bytes"},
+			"block_write_bytes": schema.Int64Attribute{Computed: true, Description: "Sum of the containers' block_write_bytes"},
+			"pids":              schema.Int64Attribute{Computed: true, Description: "Sum of the containers' pids"},
+		},
+	}
+}
+
+func (d *ProjectStatsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = setProviderClient(req, resp)
+}
+
+var projectContainerStatsType = func() types.ObjectType {
+	t := map[string]attr.Type{
+		"service":      types.StringType,
+		"container_id": types.StringType,
+		"name":         types.StringType,
+	}
+	for k, v := range containerStatsAttrTypes {
+		t[k] = v
+	}
+	return types.ObjectType{AttrTypes: t}
+}()
+
+type projectStatsModel struct {
+	EnvironmentID   types.String  `tfsdk:"environment_id"`
+	ProjectID       types.String  `tfsdk:"project_id"`
+	Services        types.List    `tfsdk:"services"`
+	Containers      types.List    `tfsdk:"containers"`
+	CPUPercent      types.Float64 `tfsdk:"cpu_percent"`
+	MemoryUsage     types.Int64   `tfsdk:"memory_usage"`
+	NetworkRxBytes  types.Int64   `tfsdk:"network_rx_bytes"`
+	NetworkTxBytes  types.Int64   `tfsdk:"network_tx_bytes"`
+	BlockReadBytes  types.Int64   `tfsdk:"block_read_bytes"`
+	BlockWriteBytes types.Int64   `tfsdk:"block_write_bytes"`
+	Pids            types.Int64   `tfsdk:"pids"`
+}
+
+type projectContainerStatsModel struct {
+	Service     types.String `tfsdk:"service"`
+	ContainerID types.String `tfsdk:"container_id"`
+	Name        types.String `tfsdk:"name"`
+	containerStatsValues
+}
+
+func (d *ProjectStatsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state projectStatsModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	envID, projID := state.EnvironmentID.ValueString(), state.ProjectID.ValueString()
+	project, err := d.client.GetProject(ctx, envID, projID)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "404") {
+			resp.Diagnostics.AddError("project not found", "No project with id: "+projID)
+			return
+		}
+		resp.Diagnostics.AddError("failed to read project", err.Error())
+		return
+	}
+
+	wanted := map[string]bool{}
+	for _, s := range listToStrings(ctx, state.Services) {
+		wanted[s] = true
+	}
+	known := map[string]bool{}
+	for _, svc := range project.RuntimeServices {
+		known[svc.Name] = true
+	}
+	for _, s := range listToStrings(ctx, state.Services) {
+		if !known[s] {
+			resp.Diagnostics.AddAttributeError(path.Root("services"), "unknown service", fmt.Sprintf("project %s has no service %q", project.Name, s))
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var targets []projectContainerStatsModel
+	for _, svc := range project.RuntimeServices {
+		if len(wanted) > 0 && !wanted[svc.Name] {
+			continue
+		}
+		// A stopped service has nothing to sample.
+		if !strings.EqualFold(svc.Status, "running") {
+			continue
+		}
+		for _, id := range serviceContainerIDs(svc) {
+			targets = append(targets, projectContainerStatsModel{Service: types.StringValue(svc.Name), ContainerID: types.StringValue(id)})
+		}
+	}
+
+	// Each sample takes the server a second or two, so a few containers are sampled at a time.
+	stats := make([]*sdkclient.ContainerStats, len(targets))
+	errs := make([]error, len(targets))
+	sem := make(chan struct{}, projectStatsConcurrency)
+	var wg sync.WaitGroup
+	for i := range targets {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			stats[i], errs[i] = d.client.GetContainerStats(ctx, envID, targets[i].ContainerID.ValueString())
+		}(i)
+	}
+	wg.Wait()
+
+	containers := make([]projectContainerStatsModel, 0, len(targets))
+	var cpu float64
+	var mem, rx, tx, read, write, pids int64
+	for i, t := range targets {
+		if err := errs[i]; err != nil {
+			// A replica removed since the project was read has nothing to report.
+			if strings.Contains(strings.ToLower(err.Error()), "404") {
+				continue
+			}
+			resp.Diagnostics.AddError("failed to read container stats", fmt.Sprintf("service %s: %s", t.Service.ValueString(), err))
+			return
+		}
+		// Docker reports a zero read time for a replica that is not running.
+		if readAt, err := time.Parse(time.RFC3339Nano, stats[i].Read); err == nil && readAt.IsZero() {
+			continue
+		}
+		t.Name = types.StringValue(strings.TrimPrefix(stats[i].Name, "/"))
+		t.containerStatsValues = statsValues(stats[i])
+		cpu += t.CPUPercent.ValueFloat64()
+		mem += t.MemoryUsage.ValueInt64()
+		rx += t.NetworkRxBytes.ValueInt64()
+		tx += t.NetworkTxBytes.ValueInt64()
+		read += t.BlockReadBytes.ValueInt64()
+		write += t.BlockWriteBytes.ValueInt64()
+		pids += t.Pids.ValueInt64()
+		containers = append(containers, t)
+	}
+
+	list, diags := types.ListValueFrom(ctx, projectContainerStatsType, containers)
+	resp.Diagnostics.Append(diags...)
+	state.Containers = list
+	state.CPUPercent = types.Float64Value(cpu)
+	state.MemoryUsage = types.Int64Value(mem)
+	state.NetworkRxBytes = types.Int64Value(rx)
+	state.NetworkTxBytes = types.Int64Value(tx)
+	state.BlockReadBytes = types.Int64Value(read)
+	state.BlockWriteBytes = types.Int64Value(write)
+	state.Pids = types.Int64Value(pids)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -53,7 +53,7 @@ func (p *ArcaneProvider) Schema(_ context.Context, _ provider.SchemaRequest, res
 				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
 			},
 			"http_timeout": schema.StringAttribute{
-				Description: "HTTP request timeout (e.g., 120s, 2m). Defaults to 120s if unset or invalid.",
+				Description: "HTTP request timeout (e.g., 120s, 2m). Defaults to 120s if unset or invalid. Resource timeouts blocks override it for their operations.",
 				Optional:    true,
 			},
 			"insecure": schema.BoolAttribute{
@@ -136,6 +136,8 @@ func (p *ArcaneProvider) DataSources(_ context.Context) []func() datasource.Data
 		// Composite ID data sources
 		NewProjectDataSource,
 		NewContainerDataSource,
+		NewContainerLogsDataSource,
+		NewContainerStatsDataSource,
 		NewNetworkDataSource,
 		NewVolumeDataSource,
 		NewGitOpsSyncDataSource,
@@ -149,6 +151,8 @@ func (p *ArcaneProvider) DataSources(_ context.Context) []func() datasource.Data
 		NewImageDataSource,
 		NewJobsDataSource,
 		NewProjectIncludesDataSource,
+		NewProjectRevisionsDataSource,
+		NewProjectStatsDataSource,
 
 		// Special cases
 		NewSettingsDataSource,
@@ -173,6 +177,8 @@ func (p *ArcaneProvider) Resources(_ context.Context) []func() resource.Resource
 		NewRegistryResource,
 		NewNotificationResource,
 		NewContainerResource,
+		NewContainerTaskResource,
+		NewContainerExecResource,
 		NewGitRepositoryResource,
 		NewGitOpsSyncResource,
 		NewApiKeyResource,
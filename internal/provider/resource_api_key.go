@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"strings"
+	"time"
 
 	"terraform-provider-arcane/internal/sdkclient"
 
@@ -16,6 +17,9 @@ import (
 
 var _ resource.Resource = &ApiKeyResource{}
 var _ resource.ResourceWithImportState = &ApiKeyResource{}
+var _ resource.ResourceWithModifyPlan = &ApiKeyResource{}
+var _ resource.ResourceWithUpgradeState = &ApiKeyResource{}
+var _ resource.ResourceWithValidateConfig = &ApiKeyResource{}
 
 type ApiKeyResource struct {
 	client *sdkclient.Client
@@ -31,6 +35,7 @@ func (r *ApiKeyResource) Metadata(_ context.Context, req resource.MetadataReques
 
 func (r *ApiKeyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = resourceschema.Schema{
+		Version:     1,
 		Description: "Manages an API key for programmatic access to Arcane.",
 		Attributes: map[string]resourceschema.Attribute{
 			"id": resourceschema.StringAttribute{
@@ -87,6 +92,12 @@ func (r *ApiKeyResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 	}
 }
 
+func (r *ApiKeyResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: passthroughStateUpgrader(),
+	}
+}
+
 func (r *ApiKeyResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData != nil {
 		if c, ok := req.ProviderData.(*sdkclient.Client); ok {
@@ -108,6 +119,49 @@ type apiKeyModel struct {
 	UpdatedAt   types.String `tfsdk:"updated_at"`
 }
 
+// ValidateConfig checks expires_at is an RFC3339 timestamp.
+func (r *ApiKeyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var cfg apiKeyModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &cfg)...)
+	if resp.Diagnostics.HasError() || cfg.ExpiresAt.IsNull() || cfg.ExpiresAt.IsUnknown() {
+		return
+	}
+	if _, err := time.Parse(time.RFC3339, cfg.ExpiresAt.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("expires_at"), "invalid expires_at", "expected an RFC3339 timestamp, e.g. 2025-12-31T23:59:59Z: "+err.Error())
+	}
+}
+
+// ModifyPlan rejects an expires_at in the past when a key is created or its
+// expiry changes. An unchanged expiry is left alone so an expired key can
+// still be planned and destroyed.
+func (r *ApiKeyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var plan apiKeyModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.ExpiresAt.IsNull() || plan.ExpiresAt.IsUnknown() {
+		return
+	}
+	t, err := time.Parse(time.RFC3339, plan.ExpiresAt.ValueString())
+	if err != nil {
+		return
+	}
+	if !req.State.Raw.IsNull() {
+		var state apiKeyModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if prior, err := time.Parse(time.RFC3339, state.ExpiresAt.ValueString()); err == nil && prior.Equal(t) {
+			return
+		}
+	}
+	if !t.After(time.Now()) {
+		resp.Diagnostics.AddAttributeError(path.Root("expires_at"), "invalid expires_at", "expires_at "+plan.ExpiresAt.ValueString()+" is in the past")
+	}
+}
+
 func (r *ApiKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan apiKeyModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
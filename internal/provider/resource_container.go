@@ -6,6 +6,9 @@ import (
 
 	"terraform-provider-arcane/internal/sdkclient"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -16,11 +19,15 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var _ resource.Resource = &ContainerResource{}
 var _ resource.ResourceWithImportState = &ContainerResource{}
+var _ resource.ResourceWithIdentity = &ContainerResource{}
+var _ resource.ResourceWithUpgradeState = &ContainerResource{}
+var _ resource.ResourceWithValidateConfig = &ContainerResource{}
 
 type ContainerResource struct{ client *sdkclient.Client }
 
@@ -30,25 +37,23 @@ func (r *ContainerResource) Metadata(_ context.Context, req resource.MetadataReq
 	resp.TypeName = req.ProviderTypeName + "_container"
 }
 
-func (r *ContainerResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
-	resp.Schema = resourceschema.Schema{
+func (r *ContainerResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	s := resourceschema.Schema{
+		Version: 2,
 		Attributes: map[string]resourceschema.Attribute{
 			"id":             resourceschema.StringAttribute{Computed: true, PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
 			"environment_id": resourceschema.StringAttribute{Required: true, Description: "Environment ID"},
-			"name":           resourceschema.StringAttribute{Required: true, Description: "Container name", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"name":           resourceschema.StringAttribute{Required: true, Description: "Container name; renamed in place"},
 			"image":          resourceschema.StringAttribute{Required: true, Description: "Image", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
 			"auto_remove":    resourceschema.BoolAttribute{Optional: true, PlanModifiers: []planmodifier.Bool{boolplanmodifier.RequiresReplace()}},
 			"command":        resourceschema.ListAttribute{Optional: true, ElementType: types.StringType, PlanModifiers: []planmodifier.List{listplanmodifier.RequiresReplace()}},
-			"cpus":           resourceschema.Float64Attribute{Optional: true, PlanModifiers: []planmodifier.Float64{float64planmodifier.RequiresReplace()}},
+			"cpus":           resourceschema.Float64Attribute{Optional: true, Description: "CPU limit; updated in place, removing it replaces the container", PlanModifiers: []planmodifier.Float64{float64planmodifier.RequiresReplaceIf(float64Removed, removedDescription, removedDescription)}},
 			"entrypoint":     resourceschema.ListAttribute{Optional: true, ElementType: types.StringType, PlanModifiers: []planmodifier.List{listplanmodifier.RequiresReplace()}},
 			"environment":    resourceschema.ListAttribute{Optional: true, ElementType: types.StringType, PlanModifiers: []planmodifier.List{listplanmodifier.RequiresReplace()}},
-			"memory":         resourceschema.Int64Attribute{Optional: true, PlanModifiers: []planmodifier.Int64{int64planmodifier.RequiresReplace()}},
-			"networks":       resourceschema.ListAttribute{Optional: true, ElementType: types.StringType, PlanModifiers: []planmodifier.List{listplanmodifier.RequiresReplace()}},
-			"ports":          resourceschema.MapAttribute{Optional: true, ElementType: types.StringType, PlanModifiers: []planmodifier.Map{mapplanmodifier.RequiresReplace()}},
+			"memory":         resourceschema.Int64Attribute{Optional: true, Description: "Memory limit in bytes; updated in place, removing it replaces the container", PlanModifiers: []planmodifier.Int64{int64planmodifier.RequiresReplaceIf(int64Removed, removedDescription, removedDescription)}},
 			"privileged":     resourceschema.BoolAttribute{Optional: true, PlanModifiers: []planmodifier.Bool{boolplanmodifier.RequiresReplace()}},
-			"restart_policy": resourceschema.StringAttribute{Optional: true, PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"restart_policy": resourceschema.StringAttribute{Optional: true, Description: "Restart policy (no, always, unless-stopped, on-failure[:N]); updated in place"},
 			"user":           resourceschema.StringAttribute{Optional: true, PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
-			"volumes":        resourceschema.ListAttribute{Optional: true, ElementType: types.StringType, PlanModifiers: []planmodifier.List{listplanmodifier.RequiresReplace()}},
 			"working_dir":    resourceschema.StringAttribute{Optional: true, PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
 
 			// Additional container configuration
@@ -63,6 +68,13 @@ func (r *ContainerResource) Schema(_ context.Context, _ resource.SchemaRequest,
 			"stdin_once":       resourceschema.BoolAttribute{Optional: true, Description: "Close stdin after client disconnect", PlanModifiers: []planmodifier.Bool{boolplanmodifier.RequiresReplace()}},
 			"network_disabled": resourceschema.BoolAttribute{Optional: true, Description: "Disable networking", PlanModifiers: []planmodifier.Bool{boolplanmodifier.RequiresReplace()}},
 
+			// Lifecycle
+			"desired_state": resourceschema.StringAttribute{
+				Optional:    true,
+				Description: "Run state to maintain: running, stopped or paused. If unset, the run state is not managed.",
+				Validators:  []validator.String{stringvalidator.OneOf(containerRunning, containerStopped, containerPaused)},
+			},
+
 			// Computed
 			"created": resourceschema.StringAttribute{Computed: true, PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
 			"status":  resourceschema.StringAttribute{Computed: true},
@@ -71,7 +83,34 @@ func (r *ContainerResource) Schema(_ context.Context, _ resource.SchemaRequest,
 			"force_delete":   resourceschema.BoolAttribute{Optional: true, Description: "Force delete running container"},
 			"remove_volumes": resourceschema.BoolAttribute{Optional: true, Description: "Remove volumes on delete"},
 		},
+		Blocks: map[string]resourceschema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Update: true, Delete: true}),
+		},
+	}
+	for _, attrs := range []map[string]resourceschema.Attribute{containerOptionAttributes(), legacyWiringAttributes()} {
+		for k, v := range attrs {
+			s.Attributes[k] = v
+		}
+	}
+	for _, blocks := range []map[string]resourceschema.Block{containerOptionBlocks(), containerWiringBlocks()} {
+		for k, v := range blocks {
+			s.Blocks[k] = v
+		}
 	}
+	resp.Schema = s
+}
+
+func (r *ContainerResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: containerStateUpgraderV2(),
+		1: containerStateUpgraderV2(),
+	}
+}
+
+var containerIdentity = envScopedIdentity(":", identityAttr{name: "id", description: "Container ID"})
+
+func (r *ContainerResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = containerIdentity.Schema()
 }
 
 func (r *ContainerResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
@@ -101,6 +140,11 @@ type containerModel struct {
 	Volumes       types.List    `tfsdk:"volumes"`
 	WorkingDir    types.String  `tfsdk:"working_dir"`
 
+	// Structured ports, mounts and networks
+	PortBlocks         types.List `tfsdk:"port"`
+	Mounts             types.List `tfsdk:"mount"`
+	NetworkAttachments types.List `tfsdk:"network_attachment"`
+
 	// Additional configuration
 	Hostname        types.String `tfsdk:"hostname"`
 	DomainName      types.String `tfsdk:"domain_name"`
@@ -113,11 +157,113 @@ type containerModel struct {
 	StdinOnce       types.Bool   `tfsdk:"stdin_once"`
 	NetworkDisabled types.Bool   `tfsdk:"network_disabled"`
 
+	// Runtime options
+	Healthcheck types.List  `tfsdk:"healthcheck"`
+	Logging     types.List  `tfsdk:"logging"`
+	Devices     types.List  `tfsdk:"device"`
+	Ulimits     types.List  `tfsdk:"ulimit"`
+	CapAdd      types.Set   `tfsdk:"cap_add"`
+	CapDrop     types.Set   `tfsdk:"cap_drop"`
+	ExtraHosts  types.List  `tfsdk:"extra_hosts"`
+	DNS         types.List  `tfsdk:"dns"`
+	Sysctls     types.Map   `tfsdk:"sysctls"`
+	ShmSize     types.Int64 `tfsdk:"shm_size"`
+	ReadOnly    types.Bool  `tfsdk:"read_only"`
+	SecurityOpt types.List  `tfsdk:"security_opt"`
+	Tmpfs       types.Map   `tfsdk:"tmpfs"`
+	Init        types.Bool  `tfsdk:"init"`
+
+	DesiredState types.String `tfsdk:"desired_state"`
+
 	Created types.String `tfsdk:"created"`
 	Status  types.String `tfsdk:"status"`
 
 	ForceDelete   types.Bool `tfsdk:"force_delete"`
 	RemoveVolumes types.Bool `tfsdk:"remove_volumes"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Values of desired_state.
+const (
+	containerRunning = "running"
+	containerStopped = "stopped"
+	containerPaused  = "paused"
+)
+
+const removedDescription = "Docker cannot remove a limit from a running container, so unsetting it replaces the container."
+
+func float64Removed(_ context.Context, req planmodifier.Float64Request, resp *float64planmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = !req.StateValue.IsNull() && req.PlanValue.IsNull()
+}
+
+func int64Removed(_ context.Context, req planmodifier.Int64Request, resp *int64planmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = !req.StateValue.IsNull() && req.PlanValue.IsNull()
+}
+
+// ValidateConfig rejects option combinations Docker refuses: auto_remove with a
+// restart policy, auto_remove with desired_state = "stopped" (stopping would
+// delete it), and malformed healthchecks and ulimits.
+func (r *ContainerResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var cfg containerModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &cfg)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(validateContainerOptions(ctx, cfg)...)
+	resp.Diagnostics.Append(validateContainerWiring(ctx, cfg)...)
+	if !cfg.AutoRemove.ValueBool() {
+		return
+	}
+	if !cfg.RestartPolicy.IsNull() && !cfg.RestartPolicy.IsUnknown() {
+		if p := cfg.RestartPolicy.ValueString(); p != "" && p != "no" {
+			resp.Diagnostics.AddAttributeError(path.Root("restart_policy"), "conflicting container options", `restart_policy must be unset or "no" when auto_remove is true`)
+		}
+	}
+	if cfg.DesiredState.ValueString() == containerStopped {
+		resp.Diagnostics.AddAttributeError(path.Root("desired_state"), "conflicting container options", `desired_state cannot be "stopped" when auto_remove is true; Docker removes the container when it stops`)
+	}
+}
+
+// containerRunState maps a container status to a desired_state value.
+func containerRunState(status string) string {
+	s := strings.ToLower(status)
+	switch {
+	case strings.Contains(s, "paused"):
+		return containerPaused
+	case s == "running" || s == "restarting" || strings.HasPrefix(s, "up"):
+		return containerRunning
+	default:
+		return containerStopped
+	}
+}
+
+// setContainerRunState moves a container from its current run state to desired.
+func setContainerRunState(ctx context.Context, c *sdkclient.Client, envID, id, current, desired string) error {
+	if current == desired {
+		return nil
+	}
+	var steps []func(context.Context, string, string) error
+	switch {
+	case current == containerPaused && desired == containerRunning:
+		steps = append(steps, c.UnpauseContainer)
+	case current == containerPaused && desired == containerStopped:
+		steps = append(steps, c.UnpauseContainer, c.StopContainer)
+	case current == containerStopped && desired == containerRunning:
+		steps = append(steps, c.StartContainer)
+	case current == containerStopped && desired == containerPaused:
+		steps = append(steps, c.StartContainer, c.PauseContainer)
+	case current == containerRunning && desired == containerStopped:
+		steps = append(steps, c.StopContainer)
+	case current == containerRunning && desired == containerPaused:
+		steps = append(steps, c.PauseContainer)
+	}
+	for _, step := range steps {
+		if err := step(ctx, envID, id); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (r *ContainerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -126,6 +272,12 @@ func (r *ContainerResource) Create(ctx context.Context, req resource.CreateReque
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts.Create)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	portMap := mapFromStringMap(ctx, plan.Ports)
 	// Normalize keys/values like "8081/tcp" => "8081"
@@ -209,8 +361,14 @@ func (r *ContainerResource) Create(ctx context.Context, req resource.CreateReque
 		v := plan.NetworkDisabled.ValueBool()
 		body.NetworkDisabled = &v
 	}
+	resp.Diagnostics.Append(applyContainerOptions(ctx, plan, &body)...)
+	resp.Diagnostics.Append(applyContainerWiring(ctx, plan, &body)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	out, err := r.client.CreateContainer(ctx, plan.EnvironmentID.ValueString(), body)
+	envID := plan.EnvironmentID.ValueString()
+	out, err := r.client.CreateContainer(ctx, envID, body)
 	if err != nil {
 		resp.Diagnostics.AddError("create container failed", err.Error())
 		return
@@ -220,8 +378,21 @@ func (r *ContainerResource) Create(ctx context.Context, req resource.CreateReque
 	state.ID = types.StringValue(out.ID)
 	state.Created = types.StringValue(out.Created)
 	state.Status = types.StringValue(out.Status)
+	if !plan.DesiredState.IsNull() && !plan.DesiredState.IsUnknown() {
+		if err := setContainerRunState(ctx, r.client, envID, out.ID, containerRunState(out.Status), plan.DesiredState.ValueString()); err != nil {
+			// The container exists; record it so Terraform taints it instead of leaking it.
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+			resp.Diagnostics.Append(containerIdentity.Sync(ctx, resp.State, resp.Identity)...)
+			resp.Diagnostics.AddError("set container state failed", err.Error())
+			return
+		}
+		if det, derr := r.client.GetContainer(ctx, envID, out.ID); derr == nil {
+			state.Status = types.StringValue(det.Status)
+		}
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(containerIdentity.Sync(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *ContainerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -241,21 +412,111 @@ func (r *ContainerResource) Read(ctx context.Context, req resource.ReadRequest,
 		resp.Diagnostics.AddError("read container failed", err.Error())
 		return
 	}
-	state.Name = types.StringValue(out.Name)
-	state.Image = types.StringValue(out.Image)
-	state.Created = types.StringValue(out.Created)
-	state.Status = types.StringValue(out.Status)
+	state = normalizeContainerBlocks(state)
+	if out.Config.Image != "" {
+		state = refreshContainerModel(ctx, state, out, containerImageDefaults(ctx, r.client, envID, out))
+		var diags diag.Diagnostics
+		state, diags = refreshContainerOptions(ctx, state, out)
+		resp.Diagnostics.Append(diags...)
+		state, diags = refreshContainerWiring(ctx, state, out)
+		resp.Diagnostics.Append(diags...)
+	} else {
+		// Servers that do not return the inspect config only report these.
+		state.Name = types.StringValue(out.Name)
+		state.Image = types.StringValue(out.Image)
+		state.Created = types.StringValue(out.Created)
+		state.Status = types.StringValue(out.Status)
+		if !state.DesiredState.IsNull() {
+			state.DesiredState = types.StringValue(containerRunState(out.Status))
+		}
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(containerIdentity.Sync(ctx, resp.State, resp.Identity)...)
 }
 
+// Update applies the changes Docker allows on an existing container: rename,
+// restart policy, CPU and memory limits and the run state. Every other
+// attribute forces replacement through its plan modifier, except rewriting
+// ports, volumes or networks as blocks (or back), which only updates state.
 func (r *ContainerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// All changes force new via plan modifiers. Nothing to do.
-	var state containerModel
+	var plan, state containerModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts.Update)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	envID := state.EnvironmentID.ValueString()
+	id := state.ID.ValueString()
+
+	if !plan.Name.Equal(state.Name) {
+		if err := r.client.RenameContainer(ctx, envID, id, plan.Name.ValueString()); err != nil {
+			resp.Diagnostics.AddError("rename container failed", err.Error())
+			return
+		}
+		state.Name = plan.Name
+	}
+
+	body := sdkclient.ContainerUpdateRequest{}
+	changed := false
+	if !plan.RestartPolicy.Equal(state.RestartPolicy) {
+		v := "no"
+		if !plan.RestartPolicy.IsNull() {
+			v = plan.RestartPolicy.ValueString()
+		}
+		body.RestartPolicy = &v
+		changed = true
+	}
+	if !plan.CPUs.Equal(state.CPUs) && !plan.CPUs.IsNull() {
+		v := plan.CPUs.ValueFloat64()
+		body.CPUs = &v
+		changed = true
+	}
+	if !plan.Memory.Equal(state.Memory) && !plan.Memory.IsNull() {
+		v := plan.Memory.ValueInt64()
+		body.Memory = &v
+		changed = true
+	}
+	if changed {
+		if err := r.client.UpdateContainer(ctx, envID, id, body); err != nil {
+			resp.Diagnostics.AddError("update container failed", err.Error())
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+			return
+		}
+		state.RestartPolicy = plan.RestartPolicy
+		state.CPUs = plan.CPUs
+		state.Memory = plan.Memory
+	}
+
+	if !plan.DesiredState.IsNull() && !plan.DesiredState.Equal(state.DesiredState) {
+		det, err := r.client.GetContainer(ctx, envID, id)
+		if err == nil {
+			err = setContainerRunState(ctx, r.client, envID, id, containerRunState(det.Status), plan.DesiredState.ValueString())
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("set container state failed", err.Error())
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+			return
+		}
+	}
+	state.DesiredState = plan.DesiredState
+	if det, err := r.client.GetContainer(ctx, envID, id); err == nil {
+		state.Status = types.StringValue(det.Status)
+	}
+
+	state.Ports, state.PortBlocks = plan.Ports, plan.PortBlocks
+	state.Volumes, state.Mounts = plan.Volumes, plan.Mounts
+	state.Networks, state.NetworkAttachments = plan.Networks, plan.NetworkAttachments
+	state.ForceDelete = plan.ForceDelete
+	state.RemoveVolumes = plan.RemoveVolumes
+	state.Timeouts = plan.Timeouts
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(containerIdentity.Sync(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *ContainerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -264,6 +525,12 @@ func (r *ContainerResource) Delete(ctx context.Context, req resource.DeleteReque
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, diags := withOperationTimeout(ctx, state.Timeouts.Delete)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	envID := state.EnvironmentID.ValueString()
 	id := state.ID.ValueString()
 	force := state.ForceDelete.ValueBool()
@@ -277,14 +544,7 @@ func (r *ContainerResource) Delete(ctx context.Context, req resource.DeleteReque
 }
 
 func (r *ContainerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// envID:containerID
-	parts := strings.SplitN(req.ID, ":", 2)
-	if len(parts) != 2 {
-		resp.Diagnostics.AddError("invalid import id", "expected env_id:container_id")
-		return
-	}
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment_id"), parts[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+	containerIdentity.ImportState(ctx, req, resp)
 }
 
 // helpers
@@ -0,0 +1,251 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"terraform-provider-arcane/internal/sdkclient"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = &ContainerExecResource{}
+
+// ContainerExecResource runs a command in a running container, e.g. a
+// post-deploy migration. Every argument forces a new run; the run happens on create.
+type ContainerExecResource struct{ client *sdkclient.Client }
+
+func NewContainerExecResource() resource.Resource { return &ContainerExecResource{} }
+
+const defaultExecOutputBytes = 64 * 1024
+
+func (r *ContainerExecResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_container_exec"
+}
+
+func (r *ContainerExecResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	replaceString := []planmodifier.String{stringplanmodifier.RequiresReplace()}
+	replaceList := []planmodifier.List{listplanmodifier.RequiresReplace()}
+	resp.Schema = resourceschema.Schema{
+		Description: "Runs a command in a running container and records its exit code and output",
+		Attributes: map[string]resourceschema.Attribute{
+			"id":             resourceschema.StringAttribute{Computed: true, Description: "Exec ID of the last run", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
+			"environment_id": resourceschema.StringAttribute{Required: true, Description: "Environment ID", PlanModifiers: replaceString},
+			"container_id": resourceschema.StringAttribute{
+				Optional: true, Description: "Container ID or name. Exactly one of container_id and project_id must be set.",
+				Validators:    []validator.String{stringvalidator.ExactlyOneOf(path.MatchRoot("project_id"))},
+				PlanModifiers: replaceString,
+			},
+			"project_id": resourceschema.StringAttribute{
+				Optional: true, Description: "Project whose service container runs the command",
+				Validators:    []validator.String{stringvalidator.AlsoRequires(path.MatchRoot("service"))},
+				PlanModifiers: replaceString,
+			},
+			"service": resourceschema.StringAttribute{
+				Optional: true, Description: "Project service; the command runs in its first running container",
+				Validators:    []validator.String{stringvalidator.AlsoRequires(path.MatchRoot("project_id"))},
+				PlanModifiers: replaceString,
+			},
+			"command": resourceschema.ListAttribute{
+				Required: true, ElementType: types.StringType, Description: "Command and arguments",
+				Validators:    []validator.List{listvalidator.SizeAtLeast(1)},
+				PlanModifiers: replaceList,
+			},
+			"environment": resourceschema.ListAttribute{Optional: true, ElementType: types.StringType, Description: "Extra environment variables as KEY=value", PlanModifiers: replaceList},
+			"user":        resourceschema.StringAttribute{Optional: true, Description: "User to run as; the container user when unset", PlanModifiers: replaceString},
+			"working_dir": resourceschema.StringAttribute{Optional: true, Description: "Working directory; the container's when unset", PlanModifiers: replaceString},
+			"triggers": resourceschema.MapAttribute{
+				Optional: true, ElementType: types.StringType,
+				Description:   "Arbitrary values; changing any of them runs the command again",
+				PlanModifiers: []planmodifier.Map{mapplanmodifier.RequiresReplace()},
+			},
+			"max_output_bytes": resourceschema.Int64Attribute{
+				Optional: true, Description: fmt.Sprintf("Bytes of stdout and of stderr to keep, from the end (default %d)", defaultExecOutputBytes),
+				Validators:    []validator.Int64{int64validator.Between(0, 4*1024*1024)},
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.RequiresReplace()},
+			},
+
+			// Computed
+			"target_container_id": resourceschema.StringAttribute{Computed: true, Description: "Container the command ran in"},
+			"exit_code":           resourceschema.Int64Attribute{Computed: true, Description: "Exit code of the command"},
+			"stdout":              resourceschema.StringAttribute{Computed: true, Description: "Standard output, truncated to max_output_bytes"},
+			"stderr":              resourceschema.StringAttribute{Computed: true, Description: "Standard error, truncated to max_output_bytes"},
+		},
+		Blocks: map[string]resourceschema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true}),
+		},
+	}
+}
+
+func (r *ContainerExecResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData != nil {
+		if c, ok := req.ProviderData.(*sdkclient.Client); ok {
+			r.client = c
+		}
+	}
+}
+
+type containerExecModel struct {
+	ID                types.String   `tfsdk:"id"`
+	EnvironmentID     types.String   `tfsdk:"environment_id"`
+	ContainerID       types.String   `tfsdk:"container_id"`
+	ProjectID         types.String   `tfsdk:"project_id"`
+	Service           types.String   `tfsdk:"service"`
+	Command           types.List     `tfsdk:"command"`
+	Environment       types.List     `tfsdk:"environment"`
+	User              types.String   `tfsdk:"user"`
+	WorkingDir        types.String   `tfsdk:"working_dir"`
+	Triggers          types.Map      `tfsdk:"triggers"`
+	MaxOutputBytes    types.Int64    `tfsdk:"max_output_bytes"`
+	TargetContainerID types.String   `tfsdk:"target_container_id"`
+	ExitCode          types.Int64    `tfsdk:"exit_code"`
+	Stdout            types.String   `tfsdk:"stdout"`
+	Stderr            types.String   `tfsdk:"stderr"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Create runs the command and waits for it within the create timeout. A
+// non-zero exit code fails the apply and records nothing, so the next apply
+// runs the command again.
+func (r *ContainerExecResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan containerExecModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	wait, diags := plan.Timeouts.Create(ctx, defaultTaskTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, wait)
+	defer cancel()
+
+	envID := plan.EnvironmentID.ValueString()
+	target, err := r.targetContainer(ctx, plan)
+	if err != nil {
+		resp.Diagnostics.AddError("resolve exec container failed", err.Error())
+		return
+	}
+
+	out, err := r.client.RunExec(ctx, envID, target, sdkclient.ExecCreateRequest{
+		Cmd:          listToStrings(ctx, plan.Command),
+		User:         plan.User.ValueString(),
+		WorkingDir:   plan.WorkingDir.ValueString(),
+		Env:          listToStrings(ctx, plan.Environment),
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			// Docker cannot cancel an exec; the command keeps running in the container.
+			err = fmt.Errorf("command did not finish within %s; it may still be running in container %s", wait, target)
+		}
+		resp.Diagnostics.AddError("exec failed", err.Error())
+		return
+	}
+
+	limit := int64(defaultExecOutputBytes)
+	if !plan.MaxOutputBytes.IsNull() && !plan.MaxOutputBytes.IsUnknown() {
+		limit = plan.MaxOutputBytes.ValueInt64()
+	}
+	state := plan
+	state.ID = types.StringValue(out.ID)
+	state.TargetContainerID = types.StringValue(target)
+	state.ExitCode = types.Int64Value(out.ExitCode)
+	state.Stdout = types.StringValue(outputTail(out.Stdout, limit))
+	state.Stderr = types.StringValue(outputTail(out.Stderr, limit))
+	if out.ExitCode != 0 {
+		resp.Diagnostics.AddError("exec failed", fmt.Sprintf("command exited with code %d", out.ExitCode)+logsDetail(strings.TrimRight(outputTail(out.Stderr+out.Stdout, 4096), "\n")))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// targetContainer returns container_id, or the first running container of the
+// project service.
+func (r *ContainerExecResource) targetContainer(ctx context.Context, plan containerExecModel) (string, error) {
+	if !plan.ContainerID.IsNull() {
+		return plan.ContainerID.ValueString(), nil
+	}
+	projID, service := plan.ProjectID.ValueString(), plan.Service.ValueString()
+	project, err := r.client.GetProject(ctx, plan.EnvironmentID.ValueString(), projID)
+	if err != nil {
+		return "", err
+	}
+	for _, svc := range project.RuntimeServices {
+		if svc.Name != service {
+			continue
+		}
+		// Replicas that are stopped, restarting or paused cannot run commands.
+		var states []string
+		for _, id := range serviceContainerIDs(svc) {
+			c, err := r.client.GetContainer(ctx, plan.EnvironmentID.ValueString(), id)
+			if err != nil {
+				return "", err
+			}
+			if c.State.Running && !c.State.Paused && !c.State.Restarting {
+				return id, nil
+			}
+			states = append(states, fmt.Sprintf("%s is %s", strings.TrimPrefix(c.Name, "/"), c.State.Status))
+		}
+		if len(states) == 0 {
+			return "", fmt.Errorf("service %q of project %s has no containers", service, projID)
+		}
+		return "", fmt.Errorf("service %q of project %s has no running container (%s)", service, projID, strings.Join(states, ", "))
+	}
+	return "", fmt.Errorf("project %s has no service %q", projID, service)
+}
+
+// Read keeps the recorded run; an exec is a past event, not a live object.
+func (r *ContainerExecResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state containerExecModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *ContainerExecResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every argument forces a new run; only the timeouts block can change in place.
+	var plan, state containerExecModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Timeouts = plan.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Delete only removes the recorded run from state; the command's effects stay.
+func (r *ContainerExecResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
+
+// outputTail keeps the last max bytes of s, starting at a rune boundary.
+func outputTail(s string, max int64) string {
+	if int64(len(s)) <= max {
+		return s
+	}
+	s = s[int64(len(s))-max:]
+	for len(s) > 0 && !utf8.RuneStart(s[0]) {
+		s = s[1:]
+	}
+	return s
+}
@@ -0,0 +1,805 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"terraform-provider-arcane/internal/sdkclient"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// The port, mount and network_attachment blocks supersede the ports, volumes
+// and networks attributes. Both forms stay accepted; a container is only
+// replaced when the ports, mounts or networks they describe change, so moving
+// a configuration from one form to the other is an in-place no-op.
+
+// containerWiring selects the ports, mounts or networks of a container.
+type containerWiring int
+
+const (
+	wiringPorts containerWiring = iota
+	wiringMounts
+	wiringNetworks
+)
+
+const (
+	portsDeprecation    = "Use port blocks instead. Existing state is migrated automatically."
+	volumesDeprecation  = "Use mount blocks instead. Existing state is migrated automatically."
+	networksDeprecation = "Use network_attachment blocks instead. Existing state is migrated automatically."
+	wiringReplaceNote   = "Replaces the container when the ports, mounts or networks it describes change."
+)
+
+var mountPropagations = []string{"private", "rprivate", "shared", "rshared", "slave", "rslave"}
+
+// legacyWiringAttributes are the deprecated string forms, kept for existing configurations.
+func legacyWiringAttributes() map[string]resourceschema.Attribute {
+	return map[string]resourceschema.Attribute{
+		"ports": resourceschema.MapAttribute{
+			Optional: true, ElementType: types.StringType, DeprecationMessage: portsDeprecation,
+			PlanModifiers: []planmodifier.Map{mapplanmodifier.RequiresReplaceIf(replaceIfWiringChangedMap(wiringPorts), wiringReplaceNote, wiringReplaceNote)},
+		},
+		"volumes": resourceschema.ListAttribute{
+			Optional: true, ElementType: types.StringType, DeprecationMessage: volumesDeprecation,
+			PlanModifiers: []planmodifier.List{listplanmodifier.RequiresReplaceIf(replaceIfWiringChanged(wiringMounts), wiringReplaceNote, wiringReplaceNote)},
+		},
+		"networks": resourceschema.ListAttribute{
+			Optional: true, ElementType: types.StringType, DeprecationMessage: networksDeprecation,
+			PlanModifiers: []planmodifier.List{listplanmodifier.RequiresReplaceIf(replaceIfWiringChanged(wiringNetworks), wiringReplaceNote, wiringReplaceNote)},
+		},
+	}
+}
+
+func containerWiringBlocks() map[string]resourceschema.Block {
+	return map[string]resourceschema.Block{
+		"port": resourceschema.ListNestedBlock{
+			Description:   "Published port or port range",
+			PlanModifiers: []planmodifier.List{listplanmodifier.RequiresReplaceIf(replaceIfWiringChanged(wiringPorts), wiringReplaceNote, wiringReplaceNote)},
+			NestedObject: resourceschema.NestedBlockObject{Attributes: map[string]resourceschema.Attribute{
+				"internal":     resourceschema.Int64Attribute{Required: true, Description: "Container port, or the first port of a range", Validators: []validator.Int64{int64validator.Between(1, 65535)}},
+				"internal_end": resourceschema.Int64Attribute{Optional: true, Description: "Last container port of a range", Validators: []validator.Int64{int64validator.Between(1, 65535)}},
+				"external":     resourceschema.Int64Attribute{Optional: true, Description: "Host port, or the first host port of a range; a random port when unset", Validators: []validator.Int64{int64validator.Between(1, 65535)}},
+				"ip":           resourceschema.StringAttribute{Optional: true, Description: "Host IP to bind to; all interfaces when unset", Validators: []validator.String{ipAddressValidator{}}},
+				"protocol":     resourceschema.StringAttribute{Optional: true, Description: "tcp (default), udp or sctp", Validators: []validator.String{stringvalidator.OneOf("tcp", "udp", "sctp")}},
+			}},
+		},
+		"mount": resourceschema.ListNestedBlock{
+			Description:   "Bind mount, volume or tmpfs",
+			PlanModifiers: []planmodifier.List{listplanmodifier.RequiresReplaceIf(replaceIfWiringChanged(wiringMounts), wiringReplaceNote, wiringReplaceNote)},
+			NestedObject:  containerMountObject(),
+		},
+		"network_attachment": resourceschema.ListNestedBlock{
+			Description:   "Network to connect the container to",
+			PlanModifiers: []planmodifier.List{listplanmodifier.RequiresReplaceIf(replaceIfWiringChanged(wiringNetworks), wiringReplaceNote, wiringReplaceNote)},
+			NestedObject:  containerNetworkAttachmentObject(),
+		},
+	}
+}
+
+// containerMountObject is the mount block, shared by every resource that creates containers.
+func containerMountObject() resourceschema.NestedBlockObject {
+	return resourceschema.NestedBlockObject{Attributes: map[string]resourceschema.Attribute{
+		"type":             resourceschema.StringAttribute{Required: true, Description: "bind, volume or tmpfs", Validators: []validator.String{stringvalidator.OneOf("bind", "volume", "tmpfs")}},
+		"source":           resourceschema.StringAttribute{Optional: true, Description: "Host path (bind) or volume name (volume); an anonymous volume when unset"},
+		"target":           resourceschema.StringAttribute{Required: true, Description: "Path in the container", Validators: []validator.String{stringvalidator.RegexMatches(absolutePathRegex, "must be an absolute path")}},
+		"read_only":        resourceschema.BoolAttribute{Optional: true, Description: "Mount read-only"},
+		"bind_propagation": resourceschema.StringAttribute{Optional: true, Description: "Bind propagation: " + strings.Join(mountPropagations, ", "), Validators: []validator.String{stringvalidator.OneOf(mountPropagations...)}},
+		"volume_subpath":   resourceschema.StringAttribute{Optional: true, Description: "Path inside the volume to mount instead of its root"},
+		"volume_no_copy":   resourceschema.BoolAttribute{Optional: true, Description: "Do not populate a new volume with the image content at target"},
+		"tmpfs_size":       resourceschema.Int64Attribute{Optional: true, Description: "tmpfs size in bytes", Validators: []validator.Int64{int64validator.AtLeast(1)}},
+	}}
+}
+
+// containerNetworkAttachmentObject is the network_attachment block, shared like containerMountObject.
+func containerNetworkAttachmentObject() resourceschema.NestedBlockObject {
+	return resourceschema.NestedBlockObject{Attributes: map[string]resourceschema.Attribute{
+		"name":         resourceschema.StringAttribute{Required: true, Description: "Network name or ID", Validators: []validator.String{stringvalidator.LengthAtLeast(1)}},
+		"aliases":      resourceschema.ListAttribute{Optional: true, ElementType: types.StringType, Description: "DNS aliases on this network"},
+		"ipv4_address": resourceschema.StringAttribute{Optional: true, Description: "Static IPv4 address", Validators: []validator.String{ipAddressValidator{}}},
+		"ipv6_address": resourceschema.StringAttribute{Optional: true, Description: "Static IPv6 address", Validators: []validator.String{ipAddressValidator{}}},
+	}}
+}
+
+type containerPortModel struct {
+	Internal    types.Int64  `tfsdk:"internal"`
+	InternalEnd types.Int64  `tfsdk:"internal_end"`
+	External    types.Int64  `tfsdk:"external"`
+	IP          types.String `tfsdk:"ip"`
+	Protocol    types.String `tfsdk:"protocol"`
+}
+
+type containerMountModel struct {
+	Type        types.String `tfsdk:"type"`
+	Source      types.String `tfsdk:"source"`
+	Target      types.String `tfsdk:"target"`
+	ReadOnly    types.Bool   `tfsdk:"read_only"`
+	Propagation types.String `tfsdk:"bind_propagation"`
+	Subpath     types.String `tfsdk:"volume_subpath"`
+	NoCopy      types.Bool   `tfsdk:"volume_no_copy"`
+	TmpfsSize   types.Int64  `tfsdk:"tmpfs_size"`
+}
+
+type containerNetworkAttachmentModel struct {
+	Name        types.String `tfsdk:"name"`
+	Aliases     types.List   `tfsdk:"aliases"`
+	IPv4Address types.String `tfsdk:"ipv4_address"`
+	IPv6Address types.String `tfsdk:"ipv6_address"`
+}
+
+var (
+	containerPortType = types.ObjectType{AttrTypes: map[string]attr.Type{
+		"internal":     types.Int64Type,
+		"internal_end": types.Int64Type,
+		"external":     types.Int64Type,
+		"ip":           types.StringType,
+		"protocol":     types.StringType,
+	}}
+	containerMountType = types.ObjectType{AttrTypes: map[string]attr.Type{
+		"type":             types.StringType,
+		"source":           types.StringType,
+		"target":           types.StringType,
+		"read_only":        types.BoolType,
+		"bind_propagation": types.StringType,
+		"volume_subpath":   types.StringType,
+		"volume_no_copy":   types.BoolType,
+		"tmpfs_size":       types.Int64Type,
+	}}
+	containerNetworkAttachmentType = types.ObjectType{AttrTypes: map[string]attr.Type{
+		"name":         types.StringType,
+		"aliases":      types.ListType{ElemType: types.StringType},
+		"ipv4_address": types.StringType,
+		"ipv6_address": types.StringType,
+	}}
+)
+
+// portSpec is a single published port; ranges are expanded port by port.
+type portSpec struct {
+	protocol string
+	ip       string
+	internal int64
+	external int64 // 0 for a random host port
+}
+
+func (p portSpec) key() string {
+	return fmt.Sprintf("%d/%s@%s:%d", p.internal, p.protocol, p.ip, p.external)
+}
+
+func newPortSpec(protocol, ip string, internal, external int64) portSpec {
+	if protocol == "" {
+		protocol = "tcp"
+	}
+	if ip == "0.0.0.0" {
+		ip = ""
+	}
+	return portSpec{protocol: protocol, ip: ip, internal: internal, external: external}
+}
+
+func portBlockSpecs(blocks []containerPortModel) []portSpec {
+	var out []portSpec
+	for _, b := range blocks {
+		first := b.Internal.ValueInt64()
+		last := first
+		if !b.InternalEnd.IsNull() {
+			last = b.InternalEnd.ValueInt64()
+		}
+		for p := first; p <= last; p++ {
+			var ext int64
+			if !b.External.IsNull() {
+				ext = b.External.ValueInt64() + p - first
+			}
+			out = append(out, newPortSpec(b.Protocol.ValueString(), b.IP.ValueString(), p, ext))
+		}
+	}
+	return out
+}
+
+// legacyPortSpecs reads the ports attribute, which only publishes TCP ports on all interfaces.
+func legacyPortSpecs(ports map[string]string) []portSpec {
+	var out []portSpec
+	for k, v := range normalizePortMap(ports) {
+		internal, _ := strconv.ParseInt(k, 10, 64)
+		external, _ := strconv.ParseInt(v, 10, 64)
+		out = append(out, newPortSpec("tcp", "", internal, external))
+	}
+	return out
+}
+
+func remotePortSpecs(bindings map[string][]sdkclient.ContainerPortBinding) []portSpec {
+	var out []portSpec
+	for key, bs := range bindings {
+		port, protocol, _ := strings.Cut(key, "/")
+		internal, _ := strconv.ParseInt(port, 10, 64)
+		for _, b := range bs {
+			external, _ := strconv.ParseInt(b.HostPort, 10, 64)
+			out = append(out, newPortSpec(protocol, b.HostIP, internal, external))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].key() < out[j].key() })
+	return out
+}
+
+func mountKey(m sdkclient.ContainerMountSpec) string {
+	var propagation, subpath string
+	var noCopy bool
+	var size int64
+	if m.BindOptions != nil {
+		propagation = m.BindOptions.Propagation
+	}
+	if m.VolumeOptions != nil {
+		subpath, noCopy = m.VolumeOptions.Subpath, m.VolumeOptions.NoCopy
+	}
+	if m.TmpfsOptions != nil {
+		size = m.TmpfsOptions.SizeBytes
+	}
+	return fmt.Sprintf("%s|%s|%s|%t|%s|%s|%t|%d", m.Type, m.Source, m.Target, m.ReadOnly, propagation, subpath, noCopy, size)
+}
+
+func mountBlockSpecs(blocks []containerMountModel) []sdkclient.ContainerMountSpec {
+	out := make([]sdkclient.ContainerMountSpec, 0, len(blocks))
+	for _, b := range blocks {
+		m := sdkclient.ContainerMountSpec{Type: b.Type.ValueString(), Source: b.Source.ValueString(), Target: b.Target.ValueString(), ReadOnly: b.ReadOnly.ValueBool()}
+		if !b.Propagation.IsNull() {
+			m.BindOptions = &sdkclient.ContainerBindOptions{Propagation: b.Propagation.ValueString()}
+		}
+		if !b.Subpath.IsNull() || b.NoCopy.ValueBool() {
+			m.VolumeOptions = &sdkclient.ContainerVolumeOptions{Subpath: b.Subpath.ValueString(), NoCopy: b.NoCopy.ValueBool()}
+		}
+		if !b.TmpfsSize.IsNull() {
+			m.TmpfsOptions = &sdkclient.ContainerTmpfsOptions{SizeBytes: b.TmpfsSize.ValueInt64()}
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
+// legacyMountSpec parses a volumes entry: "target", "source:target" or
+// "source:target:options". Sources that look like paths are bind mounts.
+func legacyMountSpec(v string) sdkclient.ContainerMountSpec {
+	parts := strings.Split(v, ":")
+	if len(parts) == 1 {
+		return sdkclient.ContainerMountSpec{Type: "volume", Target: v}
+	}
+	m := sdkclient.ContainerMountSpec{Type: "volume", Source: parts[0], Target: parts[1]}
+	if strings.HasPrefix(m.Source, "/") || strings.HasPrefix(m.Source, ".") || strings.HasPrefix(m.Source, "~") {
+		m.Type = "bind"
+	}
+	if len(parts) > 2 {
+		for _, opt := range strings.Split(parts[2], ",") {
+			switch {
+			case opt == "ro":
+				m.ReadOnly = true
+			case opt == "nocopy":
+				m.VolumeOptions = &sdkclient.ContainerVolumeOptions{NoCopy: true}
+			case m.Type == "bind" && isMountPropagation(opt):
+				m.BindOptions = &sdkclient.ContainerBindOptions{Propagation: opt}
+			}
+		}
+	}
+	return m
+}
+
+func isMountPropagation(s string) bool {
+	for _, p := range mountPropagations {
+		if s == p {
+			return true
+		}
+	}
+	return false
+}
+
+// remoteMountSpecs returns the mounts a container was created with, from either form.
+func remoteMountSpecs(det *sdkclient.ContainerDetails) []sdkclient.ContainerMountSpec {
+	out := append([]sdkclient.ContainerMountSpec(nil), det.HostConfig.Mounts...)
+	for _, b := range det.HostConfig.Binds {
+		out = append(out, legacyMountSpec(b))
+	}
+	return out
+}
+
+// networkSpec is one network attachment.
+type networkSpec struct {
+	name    string
+	aliases []string
+	ipv4    string
+	ipv6    string
+}
+
+func (n networkSpec) key() string {
+	aliases := append([]string(nil), n.aliases...)
+	sort.Strings(aliases)
+	return n.name + "|" + strings.Join(aliases, ",") + "|" + n.ipv4 + "|" + n.ipv6
+}
+
+func networkBlockSpecs(ctx context.Context, blocks []containerNetworkAttachmentModel) []networkSpec {
+	out := make([]networkSpec, 0, len(blocks))
+	for _, b := range blocks {
+		out = append(out, networkSpec{name: b.Name.ValueString(), aliases: listToStrings(ctx, b.Aliases), ipv4: b.IPv4Address.ValueString(), ipv6: b.IPv6Address.ValueString()})
+	}
+	return out
+}
+
+func legacyNetworkSpecs(names []string) []networkSpec {
+	out := make([]networkSpec, 0, len(names))
+	for _, n := range names {
+		out = append(out, networkSpec{name: n})
+	}
+	return out
+}
+
+// isDefaultNetwork reports networks Docker attaches when none is requested.
+func isDefaultNetwork(name string) bool {
+	return name == "bridge" || name == "none" || name == "host"
+}
+
+// remoteNetworkSpecs lists the attached networks. Default networks are left
+// out unless wanted names them, and the aliases Docker adds itself (the
+// container name and short ID) are dropped.
+func remoteNetworkSpecs(det *sdkclient.ContainerDetails, wanted []networkSpec) []networkSpec {
+	named := map[string]bool{}
+	for _, n := range wanted {
+		named[n.name] = true
+	}
+	implicit := map[string]bool{strings.TrimPrefix(det.Name, "/"): true, det.ID: true}
+	if len(det.ID) > 12 {
+		implicit[det.ID[:12]] = true
+	}
+	var out []networkSpec
+	for name, ep := range det.NetworkSettings.Networks {
+		if isDefaultNetwork(name) && !named[name] {
+			continue
+		}
+		n := networkSpec{name: name}
+		for _, a := range ep.Aliases {
+			if !implicit[a] {
+				n.aliases = append(n.aliases, a)
+			}
+		}
+		if ep.IPAMConfig != nil {
+			n.ipv4, n.ipv6 = ep.IPAMConfig.IPv4Address, ep.IPAMConfig.IPv6Address
+		}
+		out = append(out, n)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].name < out[j].name })
+	return out
+}
+
+func specKeys[T interface{ key() string }](specs []T) []string {
+	keys := make([]string, 0, len(specs))
+	for _, s := range specs {
+		keys = append(keys, s.key())
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func mountKeys(specs []sdkclient.ContainerMountSpec) []string {
+	keys := make([]string, 0, len(specs))
+	for _, s := range specs {
+		keys = append(keys, mountKey(s))
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// containerWiringKeys returns the canonical ports, mounts or networks of m,
+// whichever form they are written in. ok is false while any part is unknown.
+func containerWiringKeys(ctx context.Context, m containerModel, w containerWiring) (keys []string, ok bool, diags diag.Diagnostics) {
+	switch w {
+	case wiringPorts:
+		if !valueKnown(m.Ports) || !valueKnown(m.PortBlocks) {
+			return nil, false, nil
+		}
+		var blocks []containerPortModel
+		diags.Append(m.PortBlocks.ElementsAs(ctx, &blocks, false)...)
+		return specKeys(append(legacyPortSpecs(mapFromStringMap(ctx, m.Ports)), portBlockSpecs(blocks)...)), true, diags
+	case wiringMounts:
+		if !valueKnown(m.Volumes) || !valueKnown(m.Mounts) {
+			return nil, false, nil
+		}
+		var blocks []containerMountModel
+		diags.Append(m.Mounts.ElementsAs(ctx, &blocks, false)...)
+		specs := mountBlockSpecs(blocks)
+		for _, v := range listToStrings(ctx, m.Volumes) {
+			specs = append(specs, legacyMountSpec(v))
+		}
+		return mountKeys(specs), true, diags
+	default:
+		if !valueKnown(m.Networks) || !valueKnown(m.NetworkAttachments) {
+			return nil, false, nil
+		}
+		specs, d := containerNetworkSpecs(ctx, m)
+		diags.Append(d...)
+		return specKeys(specs), true, diags
+	}
+}
+
+func containerNetworkSpecs(ctx context.Context, m containerModel) ([]networkSpec, diag.Diagnostics) {
+	var blocks []containerNetworkAttachmentModel
+	diags := m.NetworkAttachments.ElementsAs(ctx, &blocks, false)
+	return append(legacyNetworkSpecs(listToStrings(ctx, m.Networks)), networkBlockSpecs(ctx, blocks)...), diags
+}
+
+// valueKnown reports whether v and everything nested in it is known.
+func valueKnown(v attr.Value) bool {
+	if v.IsUnknown() {
+		return false
+	}
+	var elems []attr.Value
+	switch c := v.(type) {
+	case basetypes.ListValue:
+		elems = c.Elements()
+	case basetypes.SetValue:
+		elems = c.Elements()
+	case basetypes.MapValue:
+		for _, e := range c.Elements() {
+			elems = append(elems, e)
+		}
+	case basetypes.ObjectValue:
+		for _, e := range c.Attributes() {
+			elems = append(elems, e)
+		}
+	}
+	for _, e := range elems {
+		if !valueKnown(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// wiringChanged reports whether the plan changes the ports, mounts or
+// networks of the container, rather than only how they are written.
+func wiringChanged(ctx context.Context, plan tfsdk.Plan, state tfsdk.State, w containerWiring) (bool, diag.Diagnostics) {
+	var p, s containerModel
+	diags := plan.Get(ctx, &p)
+	diags.Append(state.Get(ctx, &s)...)
+	if diags.HasError() {
+		return false, diags
+	}
+	pk, ok, d := containerWiringKeys(ctx, p, w)
+	diags.Append(d...)
+	if !ok {
+		return true, diags
+	}
+	sk, _, d := containerWiringKeys(ctx, s, w)
+	diags.Append(d...)
+	return !stringSlicesEqual(pk, sk), diags
+}
+
+func replaceIfWiringChanged(w containerWiring) listplanmodifier.RequiresReplaceIfFunc {
+	return func(ctx context.Context, req planmodifier.ListRequest, resp *listplanmodifier.RequiresReplaceIfFuncResponse) {
+		changed, diags := wiringChanged(ctx, req.Plan, req.State, w)
+		resp.Diagnostics.Append(diags...)
+		resp.RequiresReplace = changed
+	}
+}
+
+func replaceIfWiringChangedMap(w containerWiring) mapplanmodifier.RequiresReplaceIfFunc {
+	return func(ctx context.Context, req planmodifier.MapRequest, resp *mapplanmodifier.RequiresReplaceIfFuncResponse) {
+		changed, diags := wiringChanged(ctx, req.Plan, req.State, w)
+		resp.Diagnostics.Append(diags...)
+		resp.RequiresReplace = changed
+	}
+}
+
+// validateContainerWiring rejects mixing a deprecated attribute with its
+// block and options that do not apply to a mount type or port range.
+func validateContainerWiring(ctx context.Context, cfg containerModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, c := range []struct {
+		legacy attr.Value
+		blocks types.List
+		attr   string
+		block  string
+	}{
+		{cfg.Ports, cfg.PortBlocks, "ports", "port"},
+		{cfg.Volumes, cfg.Mounts, "volumes", "mount"},
+		{cfg.Networks, cfg.NetworkAttachments, "networks", "network_attachment"},
+	} {
+		if !c.legacy.IsNull() && !c.blocks.IsUnknown() && len(c.blocks.Elements()) > 0 {
+			diags.AddAttributeError(path.Root(c.attr), "conflicting container options", c.attr+" cannot be combined with "+c.block+" blocks")
+		}
+	}
+
+	var ports []containerPortModel
+	if !cfg.PortBlocks.IsUnknown() {
+		diags.Append(cfg.PortBlocks.ElementsAs(ctx, &ports, false)...)
+	}
+	for i, p := range ports {
+		if p.Internal.IsUnknown() || p.InternalEnd.IsUnknown() || p.External.IsUnknown() || p.InternalEnd.IsNull() {
+			continue
+		}
+		at := path.Root("port").AtListIndex(i)
+		span := p.InternalEnd.ValueInt64() - p.Internal.ValueInt64()
+		if span < 0 {
+			diags.AddAttributeError(at.AtName("internal_end"), "invalid port range", "internal_end must not be lower than internal")
+		} else if !p.External.IsNull() && p.External.ValueInt64()+span > 65535 {
+			diags.AddAttributeError(at.AtName("external"), "invalid port range", "the host port range would end above 65535")
+		}
+	}
+
+	diags.Append(validateMountBlocks(ctx, cfg.Mounts)...)
+	return diags
+}
+
+// validateMountBlocks rejects options that do not apply to a mount's type.
+func validateMountBlocks(ctx context.Context, blocks types.List) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var mounts []containerMountModel
+	if !blocks.IsUnknown() {
+		diags.Append(blocks.ElementsAs(ctx, &mounts, false)...)
+	}
+	for i, m := range mounts {
+		if m.Type.IsUnknown() {
+			continue
+		}
+		at := path.Root("mount").AtListIndex(i)
+		typ := m.Type.ValueString()
+		onlyFor := func(v attr.Value, name, want string) {
+			if !v.IsNull() && typ != want {
+				diags.AddAttributeError(at.AtName(name), "invalid mount", name+" only applies to "+want+" mounts")
+			}
+		}
+		onlyFor(m.Propagation, "bind_propagation", "bind")
+		onlyFor(m.Subpath, "volume_subpath", "volume")
+		onlyFor(m.NoCopy, "volume_no_copy", "volume")
+		onlyFor(m.TmpfsSize, "tmpfs_size", "tmpfs")
+		switch {
+		case m.Source.IsUnknown():
+		case typ == "bind" && (m.Source.IsNull() || !strings.HasPrefix(m.Source.ValueString(), "/")):
+			diags.AddAttributeError(at.AtName("source"), "invalid mount", "bind mounts need an absolute host path as source")
+		case typ == "tmpfs" && !m.Source.IsNull():
+			diags.AddAttributeError(at.AtName("source"), "invalid mount", "tmpfs mounts take no source")
+		}
+	}
+	return diags
+}
+
+// applyContainerWiring copies the port, mount and network_attachment blocks into the create request.
+func applyContainerWiring(ctx context.Context, plan containerModel, body *sdkclient.ContainerCreateRequest) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var ports []containerPortModel
+	diags.Append(plan.PortBlocks.ElementsAs(ctx, &ports, false)...)
+	for _, p := range portBlockSpecs(ports) {
+		if body.PortBindings == nil {
+			body.PortBindings = map[string][]sdkclient.ContainerPortBinding{}
+			body.ExposedPorts = map[string]struct{}{}
+		}
+		key := strconv.FormatInt(p.internal, 10) + "/" + p.protocol
+		b := sdkclient.ContainerPortBinding{HostIP: p.ip}
+		if p.external != 0 {
+			b.HostPort = strconv.FormatInt(p.external, 10)
+		}
+		body.ExposedPorts[key] = struct{}{}
+		body.PortBindings[key] = append(body.PortBindings[key], b)
+	}
+
+	diags.Append(applyMountsAndNetworks(ctx, plan.Mounts, plan.NetworkAttachments, body)...)
+	return diags
+}
+
+// applyMountsAndNetworks copies mount and network_attachment blocks into a create request.
+func applyMountsAndNetworks(ctx context.Context, mountBlocks, networkBlocks types.List, body *sdkclient.ContainerCreateRequest) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var mounts []containerMountModel
+	diags.Append(mountBlocks.ElementsAs(ctx, &mounts, false)...)
+	if len(mounts) > 0 {
+		body.Mounts = mountBlockSpecs(mounts)
+	}
+
+	var networks []containerNetworkAttachmentModel
+	diags.Append(networkBlocks.ElementsAs(ctx, &networks, false)...)
+	for _, n := range networkBlockSpecs(ctx, networks) {
+		if body.EndpointsConfig == nil {
+			body.EndpointsConfig = map[string]sdkclient.ContainerEndpointConfig{}
+		}
+		ep := sdkclient.ContainerEndpointConfig{Aliases: n.aliases}
+		if n.ipv4 != "" || n.ipv6 != "" {
+			ep.IPAMConfig = &sdkclient.ContainerEndpointIPAM{IPv4Address: n.ipv4, IPv6Address: n.ipv6}
+		}
+		body.EndpointsConfig[n.name] = ep
+	}
+	return diags
+}
+
+// refreshContainerWiring updates ports, mounts and networks from the inspect
+// payload. State is kept as is when it describes the same wiring; otherwise
+// the remote wiring is written in the form state already uses, or as blocks
+// when neither form is set.
+func refreshContainerWiring(ctx context.Context, state containerModel, det *sdkclient.ContainerDetails) (containerModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	keys, _, d := containerWiringKeys(ctx, state, wiringPorts)
+	diags.Append(d...)
+	if remote := remotePortSpecs(det.HostConfig.PortBindings); !stringSlicesEqual(keys, specKeys(remote)) {
+		if !state.Ports.IsNull() {
+			state.Ports = refreshContainerPorts(ctx, state.Ports, det.HostConfig.PortBindings)
+		} else {
+			blocks := make([]containerPortModel, 0, len(remote))
+			for _, p := range remote {
+				m := containerPortModel{Internal: types.Int64Value(p.internal), InternalEnd: types.Int64Null(), External: types.Int64Null(), IP: types.StringNull(), Protocol: types.StringNull()}
+				if p.external != 0 {
+					m.External = types.Int64Value(p.external)
+				}
+				if p.ip != "" {
+					m.IP = types.StringValue(p.ip)
+				}
+				if p.protocol != "tcp" {
+					m.Protocol = types.StringValue(p.protocol)
+				}
+				blocks = append(blocks, m)
+			}
+			state.PortBlocks, d = types.ListValueFrom(ctx, containerPortType, blocks)
+			diags.Append(d...)
+		}
+	}
+
+	keys, _, d = containerWiringKeys(ctx, state, wiringMounts)
+	diags.Append(d...)
+	if remote := remoteMountSpecs(det); !stringSlicesEqual(keys, mountKeys(remote)) {
+		if !state.Volumes.IsNull() {
+			state.Volumes = refreshContainerSet(ctx, state.Volumes, det.HostConfig.Binds)
+		} else {
+			blocks := make([]containerMountModel, 0, len(remote))
+			for _, m := range remote {
+				blocks = append(blocks, mountModelFromSpec(m))
+			}
+			state.Mounts, d = types.ListValueFrom(ctx, containerMountType, blocks)
+			diags.Append(d...)
+		}
+	}
+
+	wanted, d := containerNetworkSpecs(ctx, state)
+	diags.Append(d...)
+	if remote := remoteNetworkSpecs(det, wanted); !stringSlicesEqual(specKeys(wanted), specKeys(remote)) {
+		if !state.Networks.IsNull() {
+			names := make([]string, 0, len(remote))
+			for _, n := range remote {
+				names = append(names, n.name)
+			}
+			state.Networks = refreshContainerSet(ctx, state.Networks, names)
+		} else {
+			blocks := make([]containerNetworkAttachmentModel, 0, len(remote))
+			for _, n := range remote {
+				m := containerNetworkAttachmentModel{Name: types.StringValue(n.name), Aliases: stringsToList(ctx, n.aliases), IPv4Address: types.StringNull(), IPv6Address: types.StringNull()}
+				if n.ipv4 != "" {
+					m.IPv4Address = types.StringValue(n.ipv4)
+				}
+				if n.ipv6 != "" {
+					m.IPv6Address = types.StringValue(n.ipv6)
+				}
+				blocks = append(blocks, m)
+			}
+			state.NetworkAttachments, d = types.ListValueFrom(ctx, containerNetworkAttachmentType, blocks)
+			diags.Append(d...)
+		}
+	}
+	return state, diags
+}
+
+func mountModelFromSpec(s sdkclient.ContainerMountSpec) containerMountModel {
+	m := containerMountModel{
+		Type: types.StringValue(s.Type), Source: types.StringNull(), Target: types.StringValue(s.Target),
+		ReadOnly: types.BoolNull(), Propagation: types.StringNull(), Subpath: types.StringNull(), NoCopy: types.BoolNull(), TmpfsSize: types.Int64Null(),
+	}
+	if s.Source != "" {
+		m.Source = types.StringValue(s.Source)
+	}
+	if s.ReadOnly {
+		m.ReadOnly = types.BoolValue(true)
+	}
+	if s.BindOptions != nil && s.BindOptions.Propagation != "" {
+		m.Propagation = types.StringValue(s.BindOptions.Propagation)
+	}
+	if s.VolumeOptions != nil {
+		if s.VolumeOptions.Subpath != "" {
+			m.Subpath = types.StringValue(s.VolumeOptions.Subpath)
+		}
+		if s.VolumeOptions.NoCopy {
+			m.NoCopy = types.BoolValue(true)
+		}
+	}
+	if s.TmpfsOptions != nil && s.TmpfsOptions.SizeBytes != 0 {
+		m.TmpfsSize = types.Int64Value(s.TmpfsOptions.SizeBytes)
+	}
+	return m
+}
+
+// normalizeContainerBlocks replaces null block lists with empty ones. Absent
+// blocks plan as empty lists, so imported and upgraded state must match.
+func normalizeContainerBlocks(m containerModel) containerModel {
+	m.Healthcheck = emptyIfNull(m.Healthcheck, containerHealthcheckType)
+	m.Logging = emptyIfNull(m.Logging, containerLoggingType)
+	m.Devices = emptyIfNull(m.Devices, containerDeviceType)
+	m.Ulimits = emptyIfNull(m.Ulimits, containerUlimitType)
+	m.PortBlocks = emptyIfNull(m.PortBlocks, containerPortType)
+	m.Mounts = emptyIfNull(m.Mounts, containerMountType)
+	m.NetworkAttachments = emptyIfNull(m.NetworkAttachments, containerNetworkAttachmentType)
+	return m
+}
+
+// containerStateUpgraderV2 moves ports, volumes and networks into the port,
+// mount and network_attachment blocks. The deprecated attributes are still in
+// the schema, so prior state decodes against the current one before the move.
+func containerStateUpgraderV2() resource.StateUpgrader {
+	decode := passthroughStateUpgrader().StateUpgrader
+	return resource.StateUpgrader{
+		StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+			decode(ctx, req, resp)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			var m containerModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &m)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			m = normalizeContainerBlocks(m)
+
+			if !m.Ports.IsNull() {
+				ports := legacyPortSpecs(mapFromStringMap(ctx, m.Ports))
+				sort.Slice(ports, func(i, j int) bool { return ports[i].internal < ports[j].internal })
+				blocks := make([]containerPortModel, 0, len(ports))
+				for _, p := range ports {
+					b := containerPortModel{Internal: types.Int64Value(p.internal), InternalEnd: types.Int64Null(), External: types.Int64Null(), IP: types.StringNull(), Protocol: types.StringNull()}
+					if p.external != 0 {
+						b.External = types.Int64Value(p.external)
+					}
+					blocks = append(blocks, b)
+				}
+				v, d := types.ListValueFrom(ctx, containerPortType, blocks)
+				resp.Diagnostics.Append(d...)
+				m.PortBlocks, m.Ports = v, types.MapNull(types.StringType)
+			}
+			if !m.Volumes.IsNull() {
+				var blocks []containerMountModel
+				for _, v := range listToStrings(ctx, m.Volumes) {
+					blocks = append(blocks, mountModelFromSpec(legacyMountSpec(v)))
+				}
+				v, d := types.ListValueFrom(ctx, containerMountType, nonNilMounts(blocks))
+				resp.Diagnostics.Append(d...)
+				m.Mounts, m.Volumes = v, types.ListNull(types.StringType)
+			}
+			if !m.Networks.IsNull() {
+				var blocks []containerNetworkAttachmentModel
+				for _, n := range listToStrings(ctx, m.Networks) {
+					blocks = append(blocks, containerNetworkAttachmentModel{Name: types.StringValue(n), Aliases: types.ListNull(types.StringType), IPv4Address: types.StringNull(), IPv6Address: types.StringNull()})
+				}
+				v, d := types.ListValueFrom(ctx, containerNetworkAttachmentType, nonNilAttachments(blocks))
+				resp.Diagnostics.Append(d...)
+				m.NetworkAttachments, m.Networks = v, types.ListNull(types.StringType)
+			}
+			resp.Diagnostics.Append(resp.State.Set(ctx, &m)...)
+		},
+	}
+}
+
+func nonNilMounts(m []containerMountModel) []containerMountModel {
+	if m == nil {
+		return []containerMountModel{}
+	}
+	return m
+}
+
+func nonNilAttachments(n []containerNetworkAttachmentModel) []containerNetworkAttachmentModel {
+	if n == nil {
+		return []containerNetworkAttachmentModel{}
+	}
+	return n
+}
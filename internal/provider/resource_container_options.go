@@ -0,0 +1,514 @@
+package provider
+
+import (
+	"context"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	"terraform-provider-arcane/internal/sdkclient"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	capabilityPattern  = regexp.MustCompile(`^(ALL|(CAP_)?[A-Z][A-Z0-9_]*)$`)
+	extraHostPattern   = regexp.MustCompile(`^[^\s:]+:\S+$`)
+	sysctlPattern      = regexp.MustCompile(`^[a-z0-9_]+(\.[a-zA-Z0-9_-]+)+$`)
+	securityOptPattern = regexp.MustCompile(`^[a-z][a-z-]*([=:].+)?$`)
+	absolutePathRegex  = regexp.MustCompile(`^/`)
+)
+
+// ulimitNames are the resource limits Docker accepts.
+var ulimitNames = []string{
+	"core", "cpu", "data", "fsize", "locks", "memlock", "msgqueue", "nice",
+	"nofile", "nproc", "rss", "rtprio", "rttime", "sigpending", "stack",
+}
+
+// containerOptionAttributes are the runtime options of arcane_container
+// beyond the basic create fields. Docker cannot change any of them on an
+// existing container, so each forces replacement.
+func containerOptionAttributes() map[string]resourceschema.Attribute {
+	replaceList := []planmodifier.List{listplanmodifier.RequiresReplace()}
+	replaceSet := []planmodifier.Set{setplanmodifier.RequiresReplace()}
+	replaceMap := []planmodifier.Map{mapplanmodifier.RequiresReplace()}
+	replaceBool := []planmodifier.Bool{boolplanmodifier.RequiresReplace()}
+	return map[string]resourceschema.Attribute{
+		"cap_add": resourceschema.SetAttribute{
+			Optional: true, ElementType: types.StringType, PlanModifiers: replaceSet,
+			Description: "Kernel capabilities to add, e.g. NET_ADMIN",
+			Validators:  []validator.Set{setvalidator.ValueStringsAre(stringvalidator.RegexMatches(capabilityPattern, "must be a capability name such as NET_ADMIN or ALL"))},
+		},
+		"cap_drop": resourceschema.SetAttribute{
+			Optional: true, ElementType: types.StringType, PlanModifiers: replaceSet,
+			Description: "Kernel capabilities to drop, e.g. ALL",
+			Validators:  []validator.Set{setvalidator.ValueStringsAre(stringvalidator.RegexMatches(capabilityPattern, "must be a capability name such as NET_ADMIN or ALL"))},
+		},
+		"extra_hosts": resourceschema.ListAttribute{
+			Optional: true, ElementType: types.StringType, PlanModifiers: replaceList,
+			Description: "Extra /etc/hosts entries as host:ip",
+			Validators:  []validator.List{listvalidator.ValueStringsAre(stringvalidator.RegexMatches(extraHostPattern, "must be host:ip"))},
+		},
+		"dns": resourceschema.ListAttribute{
+			Optional: true, ElementType: types.StringType, PlanModifiers: replaceList,
+			Description: "DNS server IP addresses",
+			Validators:  []validator.List{listvalidator.ValueStringsAre(ipAddressValidator{})},
+		},
+		"sysctls": resourceschema.MapAttribute{
+			Optional: true, ElementType: types.StringType, PlanModifiers: replaceMap,
+			Description: "Namespaced kernel parameters, e.g. net.ipv4.ip_forward = \"1\"",
+			Validators:  []validator.Map{mapvalidator.KeysAre(stringvalidator.RegexMatches(sysctlPattern, "must be a sysctl name such as net.ipv4.ip_forward"))},
+		},
+		"shm_size": resourceschema.Int64Attribute{
+			Optional: true, PlanModifiers: []planmodifier.Int64{int64planmodifier.RequiresReplace()},
+			Description: "Size of /dev/shm in bytes",
+			Validators:  []validator.Int64{int64validator.AtLeast(1)},
+		},
+		"read_only": resourceschema.BoolAttribute{Optional: true, PlanModifiers: replaceBool, Description: "Mount the root filesystem read-only"},
+		"init":      resourceschema.BoolAttribute{Optional: true, PlanModifiers: replaceBool, Description: "Run an init process as PID 1 that reaps zombies and forwards signals"},
+		"security_opt": resourceschema.ListAttribute{
+			Optional: true, ElementType: types.StringType, PlanModifiers: replaceList,
+			Description: "Security options, e.g. no-new-privileges or seccomp=unconfined",
+			Validators:  []validator.List{listvalidator.ValueStringsAre(stringvalidator.RegexMatches(securityOptPattern, "must be an option such as no-new-privileges or seccomp=<profile>"))},
+		},
+		"tmpfs": resourceschema.MapAttribute{
+			Optional: true, ElementType: types.StringType, PlanModifiers: replaceMap,
+			Description: "tmpfs mounts: container path => mount options (e.g. \"size=64m,mode=1777\", or \"\" for defaults)",
+			Validators:  []validator.Map{mapvalidator.KeysAre(stringvalidator.RegexMatches(absolutePathRegex, "must be an absolute path"))},
+		},
+	}
+}
+
+func containerOptionBlocks() map[string]resourceschema.Block {
+	replaceList := []planmodifier.List{listplanmodifier.RequiresReplace()}
+	return map[string]resourceschema.Block{
+		"healthcheck": resourceschema.ListNestedBlock{
+			Description:   "Container healthcheck; overrides the image's HEALTHCHECK",
+			PlanModifiers: replaceList,
+			Validators:    []validator.List{listvalidator.SizeAtMost(1)},
+			NestedObject: resourceschema.NestedBlockObject{Attributes: map[string]resourceschema.Attribute{
+				"test": resourceschema.ListAttribute{
+					Required: true, ElementType: types.StringType,
+					Description: `Check to run: ["CMD", "arg", ...], ["CMD-SHELL", "command"], or ["NONE"] to disable the image healthcheck`,
+					Validators:  []validator.List{listvalidator.SizeAtLeast(1)},
+				},
+				"interval":     resourceschema.StringAttribute{Optional: true, Description: "Time between checks, e.g. 30s", Validators: []validator.String{durationValidator{}}},
+				"timeout":      resourceschema.StringAttribute{Optional: true, Description: "Time a check may take before it counts as failed", Validators: []validator.String{durationValidator{}}},
+				"start_period": resourceschema.StringAttribute{Optional: true, Description: "Grace period after start during which failures do not count", Validators: []validator.String{durationValidator{}}},
+				"retries":      resourceschema.Int64Attribute{Optional: true, Description: "Consecutive failures before the container is unhealthy", Validators: []validator.Int64{int64validator.AtLeast(0)}},
+			}},
+		},
+		"logging": resourceschema.ListNestedBlock{
+			Description:   "Log driver; defaults to the daemon's driver",
+			PlanModifiers: replaceList,
+			Validators:    []validator.List{listvalidator.SizeAtMost(1)},
+			NestedObject: resourceschema.NestedBlockObject{Attributes: map[string]resourceschema.Attribute{
+				"driver":  resourceschema.StringAttribute{Required: true, Description: "Log driver, e.g. json-file, local, syslog", Validators: []validator.String{stringvalidator.LengthAtLeast(1)}},
+				"options": resourceschema.MapAttribute{Optional: true, ElementType: types.StringType, Description: "Driver options, e.g. max-size = \"10m\""},
+			}},
+		},
+		"device": resourceschema.ListNestedBlock{
+			Description:   "Host device to expose in the container",
+			PlanModifiers: replaceList,
+			NestedObject: resourceschema.NestedBlockObject{Attributes: map[string]resourceschema.Attribute{
+				"host_path":      resourceschema.StringAttribute{Required: true, Description: "Device path on the host", Validators: []validator.String{stringvalidator.RegexMatches(absolutePathRegex, "must be an absolute path")}},
+				"container_path": resourceschema.StringAttribute{Optional: true, Description: "Path in the container; defaults to host_path", Validators: []validator.String{stringvalidator.RegexMatches(absolutePathRegex, "must be an absolute path")}},
+				"permissions":    resourceschema.StringAttribute{Optional: true, Description: "cgroup permissions, any of r, w, m (default rwm)", Validators: []validator.String{stringvalidator.RegexMatches(regexp.MustCompile(`^[rwm]{1,3}$`), "must combine r, w and m")}},
+			}},
+		},
+		"ulimit": resourceschema.ListNestedBlock{
+			Description:   "Resource limit for processes in the container",
+			PlanModifiers: replaceList,
+			NestedObject: resourceschema.NestedBlockObject{Attributes: map[string]resourceschema.Attribute{
+				"name": resourceschema.StringAttribute{Required: true, Description: "Limit name, e.g. nofile", Validators: []validator.String{stringvalidator.OneOf(ulimitNames...)}},
+				"soft": resourceschema.Int64Attribute{Required: true, Description: "Soft limit; -1 for unlimited", Validators: []validator.Int64{int64validator.AtLeast(-1)}},
+				"hard": resourceschema.Int64Attribute{Required: true, Description: "Hard limit; -1 for unlimited", Validators: []validator.Int64{int64validator.AtLeast(-1)}},
+			}},
+		},
+	}
+}
+
+type containerHealthcheckModel struct {
+	Test        types.List   `tfsdk:"test"`
+	Interval    types.String `tfsdk:"interval"`
+	Timeout     types.String `tfsdk:"timeout"`
+	StartPeriod types.String `tfsdk:"start_period"`
+	Retries     types.Int64  `tfsdk:"retries"`
+}
+
+type containerLoggingModel struct {
+	Driver  types.String `tfsdk:"driver"`
+	Options types.Map    `tfsdk:"options"`
+}
+
+type containerDeviceModel struct {
+	HostPath      types.String `tfsdk:"host_path"`
+	ContainerPath types.String `tfsdk:"container_path"`
+	Permissions   types.String `tfsdk:"permissions"`
+}
+
+type containerUlimitModel struct {
+	Name types.String `tfsdk:"name"`
+	Soft types.Int64  `tfsdk:"soft"`
+	Hard types.Int64  `tfsdk:"hard"`
+}
+
+var (
+	containerHealthcheckType = types.ObjectType{AttrTypes: map[string]attr.Type{
+		"test":         types.ListType{ElemType: types.StringType},
+		"interval":     types.StringType,
+		"timeout":      types.StringType,
+		"start_period": types.StringType,
+		"retries":      types.Int64Type,
+	}}
+	containerLoggingType = types.ObjectType{AttrTypes: map[string]attr.Type{
+		"driver":  types.StringType,
+		"options": types.MapType{ElemType: types.StringType},
+	}}
+	containerDeviceType = types.ObjectType{AttrTypes: map[string]attr.Type{
+		"host_path":      types.StringType,
+		"container_path": types.StringType,
+		"permissions":    types.StringType,
+	}}
+	containerUlimitType = types.ObjectType{AttrTypes: map[string]attr.Type{
+		"name": types.StringType,
+		"soft": types.Int64Type,
+		"hard": types.Int64Type,
+	}}
+)
+
+// validateContainerOptions checks what the schema validators cannot: the
+// healthcheck test form and ulimit soft/hard ordering.
+func validateContainerOptions(ctx context.Context, cfg containerModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var hcs []containerHealthcheckModel
+	if !cfg.Healthcheck.IsUnknown() {
+		diags.Append(cfg.Healthcheck.ElementsAs(ctx, &hcs, false)...)
+	}
+	for i, hc := range hcs {
+		if hc.Test.IsUnknown() {
+			continue
+		}
+		test := listToStrings(ctx, hc.Test)
+		p := path.Root("healthcheck").AtListIndex(i).AtName("test")
+		switch {
+		case len(test) == 0:
+		case test[0] == "NONE":
+			if len(test) != 1 {
+				diags.AddAttributeError(p, "invalid healthcheck", `["NONE"] takes no arguments`)
+			}
+		case test[0] == "CMD":
+			if len(test) < 2 {
+				diags.AddAttributeError(p, "invalid healthcheck", `["CMD", ...] needs the command to run`)
+			}
+		case test[0] == "CMD-SHELL":
+			if len(test) != 2 {
+				diags.AddAttributeError(p, "invalid healthcheck", `["CMD-SHELL", ...] takes exactly one shell command`)
+			}
+		default:
+			diags.AddAttributeError(p, "invalid healthcheck", `test must start with "CMD", "CMD-SHELL" or "NONE"`)
+		}
+	}
+
+	var ulimits []containerUlimitModel
+	if !cfg.Ulimits.IsUnknown() {
+		diags.Append(cfg.Ulimits.ElementsAs(ctx, &ulimits, false)...)
+	}
+	seen := map[string]bool{}
+	for i, u := range ulimits {
+		if u.Name.IsUnknown() || u.Soft.IsUnknown() || u.Hard.IsUnknown() {
+			continue
+		}
+		if seen[u.Name.ValueString()] {
+			diags.AddAttributeError(path.Root("ulimit").AtListIndex(i).AtName("name"), "duplicate ulimit", "ulimit "+u.Name.ValueString()+" is set more than once")
+		}
+		seen[u.Name.ValueString()] = true
+		soft, hard := u.Soft.ValueInt64(), u.Hard.ValueInt64()
+		if hard != -1 && (soft == -1 || soft > hard) {
+			diags.AddAttributeError(path.Root("ulimit").AtListIndex(i).AtName("soft"), "invalid ulimit", "soft limit must not exceed the hard limit")
+		}
+	}
+	return diags
+}
+
+// applyContainerOptions copies the configured runtime options into the create request.
+func applyContainerOptions(ctx context.Context, plan containerModel, body *sdkclient.ContainerCreateRequest) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var hcs []containerHealthcheckModel
+	diags.Append(plan.Healthcheck.ElementsAs(ctx, &hcs, false)...)
+	if len(hcs) > 0 {
+		hc := hcs[0]
+		body.Healthcheck = &sdkclient.ContainerHealthcheck{
+			Test:        listToStrings(ctx, hc.Test),
+			Interval:    int64(durationOrDefault(hc.Interval, 0)),
+			Timeout:     int64(durationOrDefault(hc.Timeout, 0)),
+			StartPeriod: int64(durationOrDefault(hc.StartPeriod, 0)),
+			Retries:     hc.Retries.ValueInt64(),
+		}
+	}
+
+	var logs []containerLoggingModel
+	diags.Append(plan.Logging.ElementsAs(ctx, &logs, false)...)
+	if len(logs) > 0 {
+		body.LogConfig = &sdkclient.ContainerLogConfig{Type: logs[0].Driver.ValueString()}
+		if opts := mapFromStringMap(ctx, logs[0].Options); len(opts) > 0 {
+			body.LogConfig.Config = opts
+		}
+	}
+
+	var devices []containerDeviceModel
+	diags.Append(plan.Devices.ElementsAs(ctx, &devices, false)...)
+	for _, d := range devices {
+		dev := sdkclient.ContainerDevice{PathOnHost: d.HostPath.ValueString(), PathInContainer: d.HostPath.ValueString(), CgroupPermissions: "rwm"}
+		if !d.ContainerPath.IsNull() {
+			dev.PathInContainer = d.ContainerPath.ValueString()
+		}
+		if !d.Permissions.IsNull() {
+			dev.CgroupPermissions = d.Permissions.ValueString()
+		}
+		body.Devices = append(body.Devices, dev)
+	}
+
+	var ulimits []containerUlimitModel
+	diags.Append(plan.Ulimits.ElementsAs(ctx, &ulimits, false)...)
+	for _, u := range ulimits {
+		body.Ulimits = append(body.Ulimits, sdkclient.ContainerUlimit{Name: u.Name.ValueString(), Soft: u.Soft.ValueInt64(), Hard: u.Hard.ValueInt64()})
+	}
+
+	body.CapAdd = setToStrings(ctx, plan.CapAdd)
+	body.CapDrop = setToStrings(ctx, plan.CapDrop)
+	body.ExtraHosts = listToStrings(ctx, plan.ExtraHosts)
+	body.DNS = listToStrings(ctx, plan.DNS)
+	body.SecurityOpt = listToStrings(ctx, plan.SecurityOpt)
+	if !plan.Sysctls.IsNull() && !plan.Sysctls.IsUnknown() {
+		body.Sysctls = mapFromStringMap(ctx, plan.Sysctls)
+	}
+	if !plan.Tmpfs.IsNull() && !plan.Tmpfs.IsUnknown() {
+		body.Tmpfs = mapFromStringMap(ctx, plan.Tmpfs)
+	}
+	if !plan.ShmSize.IsNull() && !plan.ShmSize.IsUnknown() {
+		v := plan.ShmSize.ValueInt64()
+		body.ShmSize = &v
+	}
+	if !plan.ReadOnly.IsNull() && !plan.ReadOnly.IsUnknown() {
+		v := plan.ReadOnly.ValueBool()
+		body.ReadonlyRootfs = &v
+	}
+	if !plan.Init.IsNull() && !plan.Init.IsUnknown() {
+		v := plan.Init.ValueBool()
+		body.Init = &v
+	}
+	return diags
+}
+
+// refreshContainerOptions maps the runtime options from the inspect payload
+// onto state. The healthcheck and log driver are only refreshed when
+// configured, since the image and the daemon supply defaults for both.
+func refreshContainerOptions(ctx context.Context, state containerModel, det *sdkclient.ContainerDetails) (containerModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	host := det.HostConfig
+
+	var hcs []containerHealthcheckModel
+	diags.Append(state.Healthcheck.ElementsAs(ctx, &hcs, false)...)
+	if len(hcs) > 0 {
+		if hc := det.Config.Healthcheck; hc == nil || len(hc.Test) == 0 {
+			state.Healthcheck = types.ListValueMust(containerHealthcheckType, []attr.Value{})
+		} else {
+			prior := hcs[0]
+			m := containerHealthcheckModel{
+				Test:        refreshContainerList(ctx, prior.Test, hc.Test, nil, true),
+				Interval:    refreshDuration(prior.Interval, hc.Interval),
+				Timeout:     refreshDuration(prior.Timeout, hc.Timeout),
+				StartPeriod: refreshDuration(prior.StartPeriod, hc.StartPeriod),
+				Retries:     prior.Retries,
+			}
+			if hc.Retries != 0 || !prior.Retries.IsNull() {
+				m.Retries = types.Int64Value(hc.Retries)
+			}
+			v, d := types.ListValueFrom(ctx, containerHealthcheckType, []containerHealthcheckModel{m})
+			diags.Append(d...)
+			state.Healthcheck = v
+		}
+	}
+
+	var logs []containerLoggingModel
+	diags.Append(state.Logging.ElementsAs(ctx, &logs, false)...)
+	if len(logs) > 0 && host.LogConfig.Type != "" {
+		m := containerLoggingModel{Driver: types.StringValue(host.LogConfig.Type), Options: logs[0].Options}
+		if !stringMapsEqual(mapFromStringMap(ctx, logs[0].Options), host.LogConfig.Config) {
+			m.Options = stringMapToMap(ctx, host.LogConfig.Config)
+		}
+		v, d := types.ListValueFrom(ctx, containerLoggingType, []containerLoggingModel{m})
+		diags.Append(d...)
+		state.Logging = v
+	}
+
+	var priorDevices []containerDeviceModel
+	diags.Append(state.Devices.ElementsAs(ctx, &priorDevices, false)...)
+	devices := make([]containerDeviceModel, 0, len(host.Devices))
+	for i, d := range host.Devices {
+		m := containerDeviceModel{HostPath: types.StringValue(d.PathOnHost), ContainerPath: types.StringValue(d.PathInContainer), Permissions: types.StringValue(d.CgroupPermissions)}
+		if i < len(priorDevices) {
+			p := priorDevices[i]
+			if p.ContainerPath.IsNull() && d.PathInContainer == d.PathOnHost {
+				m.ContainerPath = p.ContainerPath
+			}
+			if p.Permissions.IsNull() && d.CgroupPermissions == "rwm" {
+				m.Permissions = p.Permissions
+			}
+		} else {
+			if d.PathInContainer == d.PathOnHost {
+				m.ContainerPath = types.StringNull()
+			}
+			if d.CgroupPermissions == "rwm" {
+				m.Permissions = types.StringNull()
+			}
+		}
+		devices = append(devices, m)
+	}
+	if len(devices) > 0 || len(priorDevices) > 0 {
+		v, d := types.ListValueFrom(ctx, containerDeviceType, devices)
+		diags.Append(d...)
+		state.Devices = v
+	}
+
+	// The daemon merges its default ulimits into every container, so only the configured names are compared.
+	var priorUlimits []containerUlimitModel
+	diags.Append(state.Ulimits.ElementsAs(ctx, &priorUlimits, false)...)
+	if len(priorUlimits) > 0 {
+		remote := make(map[string]sdkclient.ContainerUlimit, len(host.Ulimits))
+		for _, u := range host.Ulimits {
+			remote[u.Name] = u
+		}
+		ulimits := make([]containerUlimitModel, 0, len(priorUlimits))
+		for _, p := range priorUlimits {
+			if u, ok := remote[p.Name.ValueString()]; ok {
+				ulimits = append(ulimits, containerUlimitModel{Name: p.Name, Soft: types.Int64Value(u.Soft), Hard: types.Int64Value(u.Hard)})
+			}
+		}
+		v, d := types.ListValueFrom(ctx, containerUlimitType, ulimits)
+		diags.Append(d...)
+		state.Ulimits = v
+	}
+
+	state.CapAdd = refreshCapabilities(ctx, state.CapAdd, host.CapAdd)
+	state.CapDrop = refreshCapabilities(ctx, state.CapDrop, host.CapDrop)
+	state.ExtraHosts = refreshContainerSet(ctx, state.ExtraHosts, host.ExtraHosts)
+	state.DNS = refreshContainerList(ctx, state.DNS, host.DNS, nil, true)
+	state.SecurityOpt = refreshContainerSet(ctx, state.SecurityOpt, host.SecurityOpt)
+	state.Sysctls = refreshContainerMap(ctx, state.Sysctls, host.Sysctls)
+	state.Tmpfs = refreshContainerMap(ctx, state.Tmpfs, host.Tmpfs)
+	// Without shm_size the daemon's default size applies.
+	if !state.ShmSize.IsNull() {
+		state.ShmSize = types.Int64Value(host.ShmSize)
+	}
+	state.ReadOnly = refreshContainerBool(state.ReadOnly, host.ReadonlyRootfs)
+	if host.Init != nil {
+		state.Init = refreshContainerBool(state.Init, *host.Init)
+	}
+	return state, diags
+}
+
+func emptyIfNull(v types.List, elem attr.Type) types.List {
+	if v.IsNull() {
+		return types.ListValueMust(elem, []attr.Value{})
+	}
+	return v
+}
+
+// refreshDuration keeps the configured spelling ("1m" vs "60s") when the durations match.
+func refreshDuration(prior types.String, ns int64) types.String {
+	if ns == 0 {
+		return types.StringNull()
+	}
+	if !prior.IsNull() && int64(durationOrDefault(prior, 0)) == ns {
+		return prior
+	}
+	return types.StringValue(time.Duration(ns).String())
+}
+
+// refreshCapabilities compares capabilities with and without the CAP_ prefix alike.
+func refreshCapabilities(ctx context.Context, prior types.Set, remote []string) types.Set {
+	canonical := func(c string) string {
+		c = strings.ToUpper(c)
+		if c == "ALL" || strings.HasPrefix(c, "CAP_") {
+			return c
+		}
+		return "CAP_" + c
+	}
+	a := setToStrings(ctx, prior)
+	if len(a) == len(remote) {
+		in := make(map[string]bool, len(a))
+		for _, s := range a {
+			in[canonical(s)] = true
+		}
+		same := true
+		for _, s := range remote {
+			same = same && in[canonical(s)]
+		}
+		if same {
+			return prior
+		}
+	}
+	if len(remote) == 0 {
+		return types.SetNull(types.StringType)
+	}
+	v, _ := types.SetValueFrom(ctx, types.StringType, remote)
+	return v
+}
+
+func refreshContainerMap(ctx context.Context, prior types.Map, remote map[string]string) types.Map {
+	if !prior.IsNull() && stringMapsEqual(mapFromStringMap(ctx, prior), remote) {
+		return prior
+	}
+	return stringMapToMap(ctx, remote)
+}
+
+func setToStrings(ctx context.Context, v types.Set) []string {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+	var out []string
+	_ = v.ElementsAs(ctx, &out, false)
+	return out
+}
+
+// ipAddressValidator checks a string is an IPv4 or IPv6 address.
+type ipAddressValidator struct{}
+
+var _ validator.String = ipAddressValidator{}
+
+func (ipAddressValidator) Description(_ context.Context) string {
+	return "value must be an IPv4 or IPv6 address"
+}
+
+func (v ipAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ipAddressValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if net.ParseIP(req.ConfigValue.ValueString()) == nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "invalid IP address", v.Description(ctx)+", got "+req.ConfigValue.String())
+	}
+}
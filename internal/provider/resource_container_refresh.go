@@ -0,0 +1,279 @@
+package provider
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"terraform-provider-arcane/internal/sdkclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// containerDefaults are the values Docker fills in for a container that did not
+// set them: the image config plus Docker's own defaults. known is false when the
+// image could not be inspected; unset attributes then stay unset on refresh.
+type containerDefaults struct {
+	known      bool
+	user       string
+	workingDir string
+	cmd        []string
+	entrypoint []string
+	env        map[string]string
+	labels     map[string]string
+}
+
+// containerImageDefaults inspects the image a container was created from.
+func containerImageDefaults(ctx context.Context, c *sdkclient.Client, envID string, det *sdkclient.ContainerDetails) containerDefaults {
+	ref := det.ImageID
+	if ref == "" {
+		ref = det.Image
+	}
+	img, err := c.GetImage(ctx, envID, ref)
+	if err != nil || img.Config == nil {
+		return containerDefaults{}
+	}
+	return containerDefaults{
+		known:      true,
+		user:       img.Config.User,
+		workingDir: img.Config.WorkingDir,
+		cmd:        img.Config.Cmd,
+		entrypoint: img.Config.Entrypoint,
+		env:        envMap(img.Config.Env),
+		labels:     img.Config.Labels,
+	}
+}
+
+// refreshContainerModel maps the inspect payload onto state. Values Docker
+// injected (image env and labels, the default bridge network, the ID-derived
+// hostname, zero limits, ...) are dropped so an unset attribute stays unset,
+// and values equal to state in a different spelling or order keep the state form.
+// Ports, mounts and networks are refreshed by refreshContainerWiring.
+func refreshContainerModel(ctx context.Context, state containerModel, det *sdkclient.ContainerDetails, def containerDefaults) containerModel {
+	cfg, host := det.Config, det.HostConfig
+
+	state.Name = types.StringValue(strings.TrimPrefix(det.Name, "/"))
+	state.Image = types.StringValue(det.Image)
+	if cfg.Image != "" {
+		state.Image = types.StringValue(cfg.Image)
+	}
+	state.Created = types.StringValue(det.Created)
+	state.Status = types.StringValue(det.Status)
+
+	state.Command = refreshContainerList(ctx, state.Command, cfg.Cmd, def.cmd, def.known)
+	state.Entrypoint = refreshContainerList(ctx, state.Entrypoint, cfg.Entrypoint, def.entrypoint, def.known)
+	state.Environment = refreshContainerEnv(ctx, state.Environment, cfg.Env, def)
+	state.Labels = refreshContainerLabels(ctx, state.Labels, cfg.Labels, def)
+	state.User = refreshContainerString(state.User, cfg.User, def.user, def.known)
+	state.WorkingDir = refreshContainerString(state.WorkingDir, cfg.WorkingDir, def.workingDir, def.known)
+	hostname := det.ID
+	if len(hostname) > 12 {
+		hostname = hostname[:12]
+	}
+	state.Hostname = refreshContainerString(state.Hostname, cfg.Hostname, hostname, true)
+	state.DomainName = refreshContainerString(state.DomainName, cfg.Domainname, "", true)
+
+	state.TTY = refreshContainerBool(state.TTY, cfg.Tty)
+	state.AttachStdin = refreshContainerBool(state.AttachStdin, cfg.AttachStdin)
+	state.AttachStdout = refreshContainerBool(state.AttachStdout, cfg.AttachStdout)
+	state.AttachStderr = refreshContainerBool(state.AttachStderr, cfg.AttachStderr)
+	state.OpenStdin = refreshContainerBool(state.OpenStdin, cfg.OpenStdin)
+	state.StdinOnce = refreshContainerBool(state.StdinOnce, cfg.StdinOnce)
+	state.NetworkDisabled = refreshContainerBool(state.NetworkDisabled, cfg.NetworkDisabled)
+	state.AutoRemove = refreshContainerBool(state.AutoRemove, host.AutoRemove)
+	state.Privileged = refreshContainerBool(state.Privileged, host.Privileged)
+
+	state.RestartPolicy = refreshRestartPolicy(state.RestartPolicy, host.RestartPolicy)
+	switch {
+	case host.NanoCPUs == 0:
+		state.CPUs = types.Float64Null()
+	case state.CPUs.IsNull() || math.Abs(state.CPUs.ValueFloat64()*1e9-float64(host.NanoCPUs)) >= 1:
+		state.CPUs = types.Float64Value(float64(host.NanoCPUs) / 1e9)
+	}
+	if host.Memory == 0 {
+		state.Memory = types.Int64Null()
+	} else {
+		state.Memory = types.Int64Value(host.Memory)
+	}
+
+	// A container started, stopped or paused outside Terraform shows up as a diff on desired_state.
+	if !state.DesiredState.IsNull() {
+		state.DesiredState = types.StringValue(containerRunState(det.Status))
+	}
+	return state
+}
+
+func refreshContainerString(prior types.String, remote, def string, defKnown bool) types.String {
+	if !prior.IsNull() && prior.ValueString() == remote {
+		return prior
+	}
+	if prior.IsNull() && (remote == "" || remote == def || !defKnown) {
+		return prior
+	}
+	return types.StringValue(remote)
+}
+
+// refreshContainerBool treats false as Docker's default for every flag.
+func refreshContainerBool(prior types.Bool, remote bool) types.Bool {
+	if prior.IsNull() && !remote {
+		return prior
+	}
+	return types.BoolValue(remote)
+}
+
+func refreshContainerList(ctx context.Context, prior types.List, remote, def []string, defKnown bool) types.List {
+	if !prior.IsNull() && stringSlicesEqual(listToStrings(ctx, prior), remote) {
+		return prior
+	}
+	if prior.IsNull() && (len(remote) == 0 || stringSlicesEqual(remote, def) || !defKnown) {
+		return prior
+	}
+	return stringsToList(ctx, remote)
+}
+
+// refreshContainerSet compares lists without regard to order, keeping the prior order when they match.
+func refreshContainerSet(ctx context.Context, prior types.List, remote []string) types.List {
+	if !prior.IsNull() {
+		a := append([]string(nil), listToStrings(ctx, prior)...)
+		b := append([]string(nil), remote...)
+		sort.Strings(a)
+		sort.Strings(b)
+		if stringSlicesEqual(a, b) {
+			return prior
+		}
+	}
+	return stringsToList(ctx, remote)
+}
+
+// refreshContainerEnv keeps the configured order and drops variables that
+// come unchanged from the image. Docker merges the image env in front of the
+// configured variables, so a variable set to its image value stays only if it
+// was configured.
+func refreshContainerEnv(ctx context.Context, prior types.List, remote []string, def containerDefaults) types.List {
+	values := envMap(remote)
+	configured := map[string]bool{}
+	var out []string
+	for _, e := range listToStrings(ctx, prior) {
+		k := envKey(e)
+		configured[k] = true
+		if v, ok := values[k]; ok {
+			out = append(out, k+"="+v)
+		}
+	}
+	for _, e := range remote {
+		k := envKey(e)
+		if configured[k] || !def.known {
+			continue
+		}
+		if v, ok := def.env[k]; ok && v == values[k] {
+			continue
+		}
+		out = append(out, e)
+	}
+	if !prior.IsNull() && stringSlicesEqual(listToStrings(ctx, prior), out) {
+		return prior
+	}
+	if prior.IsNull() && len(out) == 0 {
+		return prior
+	}
+	return stringsToList(ctx, out)
+}
+
+func refreshContainerLabels(ctx context.Context, prior types.Map, remote map[string]string, def containerDefaults) types.Map {
+	configured := mapFromStringMap(ctx, prior)
+	out := map[string]string{}
+	for k, v := range remote {
+		if _, ok := configured[k]; !ok {
+			if !def.known {
+				continue
+			}
+			if dv, ok := def.labels[k]; ok && dv == v {
+				continue
+			}
+		}
+		out[k] = v
+	}
+	if prior.IsNull() && len(out) == 0 {
+		return prior
+	}
+	if !prior.IsNull() && stringMapsEqual(configured, out) {
+		return prior
+	}
+	return stringMapToMap(ctx, out)
+}
+
+// refreshRestartPolicy renders the policy as restart_policy spells it; "no" is the default.
+func refreshRestartPolicy(prior types.String, p sdkclient.ContainerRestartPolicy) types.String {
+	remote := p.Name
+	if remote == "on-failure" && p.MaximumRetryCount > 0 {
+		remote += ":" + strconv.FormatInt(p.MaximumRetryCount, 10)
+	}
+	if remote == "" {
+		remote = "no"
+	}
+	if !prior.IsNull() && (prior.ValueString() == remote || prior.ValueString() == "" && remote == "no") {
+		return prior
+	}
+	if prior.IsNull() && remote == "no" {
+		return prior
+	}
+	return types.StringValue(remote)
+}
+
+// refreshContainerPorts maps port bindings to the ports attribute form
+// (container port => host port). TCP ports drop the protocol suffix the way
+// normalizePortMap does; other protocols keep it so they show as drift.
+func refreshContainerPorts(ctx context.Context, prior types.Map, bindings map[string][]sdkclient.ContainerPortBinding) types.Map {
+	remote := map[string]string{}
+	for port, b := range bindings {
+		if len(b) == 0 {
+			continue
+		}
+		remote[strings.TrimSuffix(port, "/tcp")] = b[0].HostPort
+	}
+	if !prior.IsNull() && stringMapsEqual(normalizePortMap(mapFromStringMap(ctx, prior)), remote) {
+		return prior
+	}
+	return stringMapToMap(ctx, remote)
+}
+
+func envKey(e string) string {
+	if i := strings.IndexByte(e, '='); i >= 0 {
+		return e[:i]
+	}
+	return e
+}
+
+func envMap(env []string) map[string]string {
+	out := make(map[string]string, len(env))
+	for _, e := range env {
+		out[envKey(e)] = strings.TrimPrefix(e[len(envKey(e)):], "=")
+	}
+	return out
+}
+
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func stringMapsEqual(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if w, ok := b[k]; !ok || w != v {
+			return false
+		}
+	}
+	return true
+}
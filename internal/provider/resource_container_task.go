@@ -0,0 +1,346 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"terraform-provider-arcane/internal/sdkclient"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = &ContainerTaskResource{}
+var _ resource.ResourceWithValidateConfig = &ContainerTaskResource{}
+
+// ContainerTaskResource runs a container to completion, e.g. a migration or
+// seed job. Every argument forces a new run; the run happens on create.
+type ContainerTaskResource struct{ client *sdkclient.Client }
+
+func NewContainerTaskResource() resource.Resource { return &ContainerTaskResource{} }
+
+const (
+	defaultTaskTimeout  = 10 * time.Minute
+	taskPollInterval    = 2 * time.Second
+	defaultTaskLogLines = 50
+)
+
+func (r *ContainerTaskResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_container_task"
+}
+
+func (r *ContainerTaskResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	replaceString := []planmodifier.String{stringplanmodifier.RequiresReplace()}
+	replaceList := []planmodifier.List{listplanmodifier.RequiresReplace()}
+	resp.Schema = resourceschema.Schema{
+		Description: "Runs a one-shot container to completion and records its exit code and output",
+		Attributes: map[string]resourceschema.Attribute{
+			"id":             resourceschema.StringAttribute{Computed: true, Description: "ID of the container of the last run", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
+			"environment_id": resourceschema.StringAttribute{Required: true, Description: "Environment ID", PlanModifiers: replaceString},
+			"image":          resourceschema.StringAttribute{Required: true, Description: "Image to run", PlanModifiers: replaceString},
+			"name":           resourceschema.StringAttribute{Optional: true, Description: "Container name; generated when unset", PlanModifiers: replaceString},
+			"command":        resourceschema.ListAttribute{Optional: true, ElementType: types.StringType, Description: "Command to run", PlanModifiers: replaceList},
+			"entrypoint":     resourceschema.ListAttribute{Optional: true, ElementType: types.StringType, Description: "Entrypoint override", PlanModifiers: replaceList},
+			"environment":    resourceschema.ListAttribute{Optional: true, ElementType: types.StringType, Description: "Environment variables as KEY=value", PlanModifiers: replaceList},
+			"user":           resourceschema.StringAttribute{Optional: true, Description: "User to run as", PlanModifiers: replaceString},
+			"working_dir":    resourceschema.StringAttribute{Optional: true, Description: "Working directory", PlanModifiers: replaceString},
+			"triggers": resourceschema.MapAttribute{
+				Optional: true, ElementType: types.StringType,
+				Description:   "Arbitrary values; changing any of them runs the task again",
+				PlanModifiers: []planmodifier.Map{mapplanmodifier.RequiresReplace()},
+			},
+			"log_lines": resourceschema.Int64Attribute{
+				Optional: true, Description: fmt.Sprintf("Number of trailing log lines to keep in logs (default %d)", defaultTaskLogLines),
+				Validators:    []validator.Int64{int64validator.Between(0, 10000)},
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.RequiresReplace()},
+			},
+			"keep_container": resourceschema.BoolAttribute{
+				Optional: true, Description: "Keep the exited container until the task is destroyed or re-run instead of removing it after the run",
+				PlanModifiers: []planmodifier.Bool{boolplanmodifier.RequiresReplace()},
+			},
+
+			// Computed
+			"exit_code":   resourceschema.Int64Attribute{Computed: true, Description: "Exit code of the run"},
+			"logs":        resourceschema.StringAttribute{Computed: true, Description: "Last log_lines lines of stdout and stderr"},
+			"started_at":  resourceschema.StringAttribute{Computed: true, Description: "RFC3339 start time"},
+			"finished_at": resourceschema.StringAttribute{Computed: true, Description: "RFC3339 finish time"},
+		},
+		Blocks: map[string]resourceschema.Block{
+			"mount": resourceschema.ListNestedBlock{
+				Description:   "Bind mount, volume or tmpfs",
+				PlanModifiers: replaceList,
+				NestedObject:  containerMountObject(),
+			},
+			"network_attachment": resourceschema.ListNestedBlock{
+				Description:   "Network to connect the task to, e.g. to reach a database",
+				PlanModifiers: replaceList,
+				NestedObject:  containerNetworkAttachmentObject(),
+			},
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Delete: true}),
+		},
+	}
+}
+
+func (r *ContainerTaskResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData != nil {
+		if c, ok := req.ProviderData.(*sdkclient.Client); ok {
+			r.client = c
+		}
+	}
+}
+
+type containerTaskModel struct {
+	ID                 types.String   `tfsdk:"id"`
+	EnvironmentID      types.String   `tfsdk:"environment_id"`
+	Image              types.String   `tfsdk:"image"`
+	Name               types.String   `tfsdk:"name"`
+	Command            types.List     `tfsdk:"command"`
+	Entrypoint         types.List     `tfsdk:"entrypoint"`
+	Environment        types.List     `tfsdk:"environment"`
+	User               types.String   `tfsdk:"user"`
+	WorkingDir         types.String   `tfsdk:"working_dir"`
+	Triggers           types.Map      `tfsdk:"triggers"`
+	LogLines           types.Int64    `tfsdk:"log_lines"`
+	KeepContainer      types.Bool     `tfsdk:"keep_container"`
+	Mounts             types.List     `tfsdk:"mount"`
+	NetworkAttachments types.List     `tfsdk:"network_attachment"`
+	ExitCode           types.Int64    `tfsdk:"exit_code"`
+	Logs               types.String   `tfsdk:"logs"`
+	StartedAt          types.String   `tfsdk:"started_at"`
+	FinishedAt         types.String   `tfsdk:"finished_at"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *ContainerTaskResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var cfg containerTaskModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &cfg)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(validateMountBlocks(ctx, cfg.Mounts)...)
+}
+
+// Create runs the task: it creates and starts the container, waits for it to
+// exit within the create timeout and fails on a non-zero exit code.
+func (r *ContainerTaskResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan containerTaskModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	wait, diags := plan.Timeouts.Create(ctx, defaultTaskTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, wait)
+	defer cancel()
+
+	autoRemove := false
+	body := sdkclient.ContainerCreateRequest{
+		Name:        plan.Name.ValueString(),
+		Image:       plan.Image.ValueString(),
+		AutoRemove:  &autoRemove,
+		Command:     listToStrings(ctx, plan.Command),
+		Entrypoint:  listToStrings(ctx, plan.Entrypoint),
+		Environment: listToStrings(ctx, plan.Environment),
+	}
+	if !plan.User.IsNull() && !plan.User.IsUnknown() {
+		v := plan.User.ValueString()
+		body.User = &v
+	}
+	if !plan.WorkingDir.IsNull() && !plan.WorkingDir.IsUnknown() {
+		v := plan.WorkingDir.ValueString()
+		body.WorkingDir = &v
+	}
+	resp.Diagnostics.Append(applyMountsAndNetworks(ctx, plan.Mounts, plan.NetworkAttachments, &body)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	envID := plan.EnvironmentID.ValueString()
+	out, err := r.client.CreateContainer(ctx, envID, body)
+	if err != nil {
+		resp.Diagnostics.AddError("create task container failed", err.Error())
+		return
+	}
+	id := out.ID
+	keep := plan.KeepContainer.ValueBool()
+	// Remove the container however the run ends, unless asked to keep it.
+	defer func() {
+		if !keep {
+			_ = r.client.DeleteContainer(context.WithoutCancel(ctx), envID, id, true, false)
+		}
+	}()
+
+	if strings.EqualFold(out.Status, "created") {
+		if err := r.client.StartContainer(ctx, envID, id); err != nil {
+			resp.Diagnostics.AddError("start task container failed", err.Error())
+			keep = false
+			return
+		}
+	}
+
+	det, err := waitForContainerExit(ctx, r.client, envID, id)
+	if err != nil {
+		if ctx.Err() != nil {
+			err = fmt.Errorf("task did not finish within %s", wait)
+		}
+		resp.Diagnostics.AddError("task failed", err.Error()+taskLogsDetail(ctx, r.client, envID, id, plan.LogLines))
+		keep = false
+		return
+	}
+
+	state := plan
+	state.ID = types.StringValue(id)
+	code, _ := containerExitCode(det)
+	state.ExitCode = types.Int64Value(code)
+	state.StartedAt = types.StringValue(det.State.StartedAt)
+	state.FinishedAt = types.StringValue(det.State.FinishedAt)
+	state.Logs = types.StringValue("")
+	logs, err := taskLogs(ctx, r.client, envID, id, plan.LogLines)
+	if err != nil {
+		resp.Diagnostics.AddWarning("read task logs failed", err.Error())
+	} else {
+		state.Logs = types.StringValue(logs)
+	}
+
+	if code != 0 {
+		// A kept container is recorded so the next apply replaces it; otherwise
+		// nothing is recorded and the next apply runs the task again.
+		if keep {
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		}
+		resp.Diagnostics.AddError("task failed", fmt.Sprintf("container exited with code %d", code)+logsDetail(state.Logs.ValueString()))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Read keeps the recorded run; a task is a past event, not a live object.
+func (r *ContainerTaskResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state containerTaskModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *ContainerTaskResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every argument forces a new run; only the timeouts block can change in place.
+	var plan, state containerTaskModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Timeouts = plan.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Delete removes a kept container; other runs left nothing behind.
+func (r *ContainerTaskResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state containerTaskModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || !state.KeepContainer.ValueBool() {
+		return
+	}
+	ctx, cancel, diags := withOperationTimeout(ctx, state.Timeouts.Delete)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := r.client.DeleteContainer(ctx, state.EnvironmentID.ValueString(), state.ID.ValueString(), true, false); err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "404") {
+			return
+		}
+		resp.Diagnostics.AddError("delete task container failed", err.Error())
+	}
+}
+
+// waitForContainerExit polls a container until it has exited. It returns when
+// ctx is done, so callers bound the wait with ctx.
+func waitForContainerExit(ctx context.Context, c *sdkclient.Client, envID, id string) (*sdkclient.ContainerDetails, error) {
+	for {
+		det, err := c.GetContainer(ctx, envID, id)
+		if err != nil {
+			return nil, err
+		}
+		if _, done := containerExitCode(det); done {
+			return det, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(taskPollInterval):
+		}
+	}
+}
+
+var exitedStatus = regexp.MustCompile(`(?i)^exited \((-?\d+)\)`)
+
+// containerExitCode returns the exit code of a container that has stopped
+// running; done is false while it is still created, running or restarting.
+func containerExitCode(det *sdkclient.ContainerDetails) (code int64, done bool) {
+	switch strings.ToLower(det.State.Status) {
+	case "exited", "dead":
+		return det.State.ExitCode, true
+	case "":
+	default:
+		return 0, false
+	}
+	// Servers without the state object only report a summary such as "Exited (1) 2 seconds ago".
+	if m := exitedStatus.FindStringSubmatch(det.Status); m != nil {
+		code, _ = strconv.ParseInt(m[1], 10, 64)
+		return code, true
+	}
+	return 0, false
+}
+
+func taskLogs(ctx context.Context, c *sdkclient.Client, envID, id string, lines types.Int64) (string, error) {
+	n := int64(defaultTaskLogLines)
+	if !lines.IsNull() && !lines.IsUnknown() {
+		n = lines.ValueInt64()
+	}
+	if n == 0 {
+		return "", nil
+	}
+	out, err := c.GetContainerLogs(ctx, envID, id, sdkclient.ContainerLogsOptions{Tail: n, Stdout: true, Stderr: true})
+	if err != nil {
+		return "", err
+	}
+	msgs := make([]string, 0, len(out))
+	for _, l := range out {
+		msgs = append(msgs, strings.TrimRight(l.Message, "\n"))
+	}
+	return strings.Join(msgs, "\n"), nil
+}
+
+// taskLogsDetail fetches the log tail for an error message, ignoring failures.
+func taskLogsDetail(ctx context.Context, c *sdkclient.Client, envID, id string, lines types.Int64) string {
+	logs, _ := taskLogs(context.WithoutCancel(ctx), c, envID, id, lines)
+	return logsDetail(logs)
+}
+
+func logsDetail(logs string) string {
+	if logs == "" {
+		return ""
+	}
+	return "\n\nLast log lines:\n" + logs
+}
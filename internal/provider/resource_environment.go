@@ -6,6 +6,7 @@ import (
 
 	"terraform-provider-arcane/internal/sdkclient"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -16,6 +17,7 @@ import (
 
 var _ resource.Resource = &EnvironmentResource{}
 var _ resource.ResourceWithImportState = &EnvironmentResource{}
+var _ resource.ResourceWithUpgradeState = &EnvironmentResource{}
 
 type EnvironmentResource struct{ client *sdkclient.Client }
 
@@ -25,8 +27,9 @@ func (r *EnvironmentResource) Metadata(_ context.Context, req resource.MetadataR
 	resp.TypeName = req.ProviderTypeName + "_environment"
 }
 
-func (r *EnvironmentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *EnvironmentResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = resourceschema.Schema{
+		Version: 1,
 		Attributes: map[string]resourceschema.Attribute{
 			"id": resourceschema.StringAttribute{
 				Computed:    true,
@@ -76,6 +79,15 @@ func (r *EnvironmentResource) Schema(_ context.Context, _ resource.SchemaRequest
 				},
 			},
 		},
+		Blocks: map[string]resourceschema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Update: true, Delete: true}),
+		},
+	}
+}
+
+func (r *EnvironmentResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: passthroughStateUpgrader(),
 	}
 }
 
@@ -97,6 +109,8 @@ type environmentModel struct {
 	Enabled        types.Bool   `tfsdk:"enabled"`
 	Status         types.String `tfsdk:"status"`
 	APIKey         types.String `tfsdk:"api_key"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *EnvironmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -105,6 +119,12 @@ func (r *EnvironmentResource) Create(ctx context.Context, req resource.CreateReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts.Create)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	body := sdkclient.EnvironmentCreateRequest{
 		APIURL: plan.APIURL.ValueString(),
@@ -145,6 +165,7 @@ func (r *EnvironmentResource) Create(ctx context.Context, req resource.CreateReq
 		UseAPIKey:      plan.UseAPIKey,
 		Enabled:        plan.Enabled,
 		Status:         types.StringValue(env.Status),
+		Timeouts:       plan.Timeouts,
 	}
 	if env.APIKey != "" {
 		state.APIKey = types.StringValue(env.APIKey)
@@ -186,6 +207,12 @@ func (r *EnvironmentResource) Update(ctx context.Context, req resource.UpdateReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts.Update)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	body := sdkclient.EnvironmentUpdateRequest{}
 	if !plan.APIURL.IsNull() && !plan.APIURL.IsUnknown() {
@@ -228,6 +255,7 @@ func (r *EnvironmentResource) Update(ctx context.Context, req resource.UpdateReq
 	if !plan.UseAPIKey.IsNull() && !plan.UseAPIKey.IsUnknown() {
 		state.UseAPIKey = plan.UseAPIKey
 	}
+	state.Timeouts = plan.Timeouts
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -237,6 +265,12 @@ func (r *EnvironmentResource) Delete(ctx context.Context, req resource.DeleteReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, diags := withOperationTimeout(ctx, state.Timeouts.Delete)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	if err := r.client.DeleteEnvironment(ctx, state.ID.ValueString()); err != nil {
 		if strings.Contains(strings.ToLower(err.Error()), "404") {
 			return
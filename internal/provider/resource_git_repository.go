@@ -16,6 +16,8 @@ import (
 
 var _ resource.Resource = &GitRepositoryResource{}
 var _ resource.ResourceWithImportState = &GitRepositoryResource{}
+var _ resource.ResourceWithUpgradeState = &GitRepositoryResource{}
+var _ resource.ResourceWithValidateConfig = &GitRepositoryResource{}
 
 type GitRepositoryResource struct {
 	client *sdkclient.Client
@@ -51,6 +53,7 @@ func (r *GitRepositoryResource) Metadata(_ context.Context, req resource.Metadat
 
 func (r *GitRepositoryResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = resourceschema.Schema{
+		Version: 1,
 		Attributes: map[string]resourceschema.Attribute{
 			"id": resourceschema.StringAttribute{
 				Computed:    true,
@@ -111,6 +114,12 @@ func (r *GitRepositoryResource) Schema(_ context.Context, _ resource.SchemaReque
 	}
 }
 
+func (r *GitRepositoryResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: passthroughStateUpgrader(),
+	}
+}
+
 func (r *GitRepositoryResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData != nil {
 		if c, ok := req.ProviderData.(*sdkclient.Client); ok {
@@ -133,6 +142,25 @@ type gitRepositoryModel struct {
 	UpdatedAt   types.String `tfsdk:"updated_at"`
 }
 
+// ValidateConfig requires the credential matching auth_type so a missing key is caught at validate time.
+func (r *GitRepositoryResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var cfg gitRepositoryModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &cfg)...)
+	if resp.Diagnostics.HasError() || cfg.AuthType.IsUnknown() {
+		return
+	}
+	switch cfg.AuthType.ValueString() {
+	case "ssh":
+		if cfg.SSHKey.IsNull() || (!cfg.SSHKey.IsUnknown() && cfg.SSHKey.ValueString() == "") {
+			resp.Diagnostics.AddAttributeError(path.Root("ssh_key"), "missing ssh_key", `ssh_key is required when auth_type is "ssh"`)
+		}
+	case "token":
+		if cfg.Token.IsNull() || (!cfg.Token.IsUnknown() && cfg.Token.ValueString() == "") {
+			resp.Diagnostics.AddAttributeError(path.Root("token"), "missing token", `token is required when auth_type is "token"`)
+		}
+	}
+}
+
 func (r *GitRepositoryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan gitRepositoryModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -7,7 +7,7 @@ import (
 
 	"terraform-provider-arcane/internal/sdkclient"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -17,6 +17,8 @@ import (
 
 var _ resource.Resource = &GitOpsSyncResource{}
 var _ resource.ResourceWithImportState = &GitOpsSyncResource{}
+var _ resource.ResourceWithIdentity = &GitOpsSyncResource{}
+var _ resource.ResourceWithUpgradeState = &GitOpsSyncResource{}
 
 type GitOpsSyncResource struct {
 	client *sdkclient.Client
@@ -30,8 +32,9 @@ func (r *GitOpsSyncResource) Metadata(_ context.Context, req resource.MetadataRe
 	resp.TypeName = req.ProviderTypeName + "_gitops_sync"
 }
 
-func (r *GitOpsSyncResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *GitOpsSyncResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = resourceschema.Schema{
+		Version: 1,
 		Attributes: map[string]resourceschema.Attribute{
 			"id": resourceschema.StringAttribute{
 				Computed:    true,
@@ -125,9 +128,24 @@ func (r *GitOpsSyncResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				},
 			},
 		},
+		Blocks: map[string]resourceschema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Update: true, Delete: true}),
+		},
 	}
 }
 
+func (r *GitOpsSyncResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: passthroughStateUpgrader(),
+	}
+}
+
+var gitOpsSyncIdentity = envScopedIdentity(":", identityAttr{name: "id", description: "GitOps sync ID"})
+
+func (r *GitOpsSyncResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = gitOpsSyncIdentity.Schema()
+}
+
 func (r *GitOpsSyncResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData != nil {
 		if c, ok := req.ProviderData.(*sdkclient.Client); ok {
@@ -156,6 +174,8 @@ type gitOpsSyncModel struct {
 	LastSyncError        types.String `tfsdk:"last_sync_error"`
 	CreatedAt            types.String `tfsdk:"created_at"`
 	UpdatedAt            types.String `tfsdk:"updated_at"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 // mapToEnvContent converts a Terraform map to .env file format
@@ -213,6 +233,12 @@ func (r *GitOpsSyncResource) Create(ctx context.Context, req resource.CreateRequ
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts.Create)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	body := sdkclient.GitOpsSyncCreateRequest{
 		Name:         plan.Name.ValueString(),
@@ -286,6 +312,7 @@ func (r *GitOpsSyncResource) Create(ctx context.Context, req resource.CreateRequ
 		StartProject:         plan.StartProject, // Preserve the user's preference
 		CreatedAt:            types.StringValue(sync.CreatedAt),
 		UpdatedAt:            types.StringValue(sync.UpdatedAt),
+		Timeouts:             plan.Timeouts,
 	}
 
 	if sync.ProjectID != nil {
@@ -305,6 +332,7 @@ func (r *GitOpsSyncResource) Create(ctx context.Context, req resource.CreateRequ
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(gitOpsSyncIdentity.Sync(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *GitOpsSyncResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -375,6 +403,7 @@ func (r *GitOpsSyncResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(gitOpsSyncIdentity.Sync(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *GitOpsSyncResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -385,6 +414,12 @@ func (r *GitOpsSyncResource) Update(ctx context.Context, req resource.UpdateRequ
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts.Update)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	body := sdkclient.GitOpsSyncUpdateRequest{}
 
@@ -457,6 +492,7 @@ func (r *GitOpsSyncResource) Update(ctx context.Context, req resource.UpdateRequ
 	// Leave updated_at unchanged to avoid plan inconsistency on server-side timestamp changes
 	state.EnvironmentVariables = plan.EnvironmentVariables
 	state.StartProject = plan.StartProject // Preserve the user's preference
+	state.Timeouts = plan.Timeouts
 
 	if sync.ProjectID != nil {
 		state.ProjectID = types.StringValue(*sync.ProjectID)
@@ -486,6 +522,7 @@ func (r *GitOpsSyncResource) Update(ctx context.Context, req resource.UpdateRequ
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(gitOpsSyncIdentity.Sync(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *GitOpsSyncResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -494,6 +531,12 @@ func (r *GitOpsSyncResource) Delete(ctx context.Context, req resource.DeleteRequ
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, diags := withOperationTimeout(ctx, state.Timeouts.Delete)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	envID := state.EnvironmentID.ValueString()
 	syncID := state.ID.ValueString()
@@ -537,12 +580,5 @@ func (r *GitOpsSyncResource) Delete(ctx context.Context, req resource.DeleteRequ
 }
 
 func (r *GitOpsSyncResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// envID:syncID
-	parts := strings.SplitN(req.ID, ":", 2)
-	if len(parts) != 2 {
-		resp.Diagnostics.AddError("invalid import id", "expected env_id:sync_id")
-		return
-	}
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment_id"), parts[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+	gitOpsSyncIdentity.ImportState(ctx, req, resp)
 }
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// compositeIdentity describes a resource addressed by several string attributes
+// (e.g. environment_id + id). Each identity attribute mirrors the state attribute
+// of the same name; legacy import IDs list the values in order joined by sep.
+type compositeIdentity struct {
+	sep   string
+	attrs []identityAttr
+}
+
+type identityAttr struct {
+	name        string
+	description string
+}
+
+var envIdentityAttr = identityAttr{name: "environment_id", description: "Environment ID"}
+
+// envScopedIdentity returns the identity used by resources keyed by environment ID plus a local key.
+func envScopedIdentity(sep string, key identityAttr) compositeIdentity {
+	return compositeIdentity{sep: sep, attrs: []identityAttr{envIdentityAttr, key}}
+}
+
+// Schema returns the identity schema; every attribute is required for import.
+func (c compositeIdentity) Schema() identityschema.Schema {
+	attrs := make(map[string]identityschema.Attribute, len(c.attrs))
+	for _, a := range c.attrs {
+		attrs[a.name] = identityschema.StringAttribute{RequiredForImport: true, Description: a.description}
+	}
+	return identityschema.Schema{Attributes: attrs}
+}
+
+// importFormat renders the legacy import ID format, e.g. environment_id:id.
+func (c compositeIdentity) importFormat() string {
+	names := make([]string, len(c.attrs))
+	for i, a := range c.attrs {
+		names[i] = a.name
+	}
+	return strings.Join(names, c.sep)
+}
+
+// ImportState populates state from either a legacy import ID or an identity block.
+func (c compositeIdentity) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	values := make([]string, len(c.attrs))
+	if req.ID != "" {
+		parts := strings.SplitN(req.ID, c.sep, len(c.attrs))
+		if len(parts) != len(c.attrs) {
+			resp.Diagnostics.AddError("invalid import id", "expected "+c.importFormat())
+			return
+		}
+		copy(values, parts)
+	} else {
+		for i, a := range c.attrs {
+			var v types.String
+			resp.Diagnostics.Append(req.Identity.GetAttribute(ctx, path.Root(a.name), &v)...)
+			values[i] = v.ValueString()
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	for i, a := range c.attrs {
+		if values[i] == "" {
+			resp.Diagnostics.AddAttributeError(path.Root(a.name), "invalid import identity", a.name+" must not be empty")
+			continue
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(a.name), values[i])...)
+		if resp.Identity != nil {
+			resp.Diagnostics.Append(resp.Identity.SetAttribute(ctx, path.Root(a.name), values[i])...)
+		}
+	}
+}
+
+// Sync copies the identity attributes from state into the response identity.
+// Call it after every successful State.Set in Create, Read and Update.
+func (c compositeIdentity) Sync(ctx context.Context, state tfsdk.State, identity *tfsdk.ResourceIdentity) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if identity == nil {
+		return diags
+	}
+	for _, a := range c.attrs {
+		var v types.String
+		diags.Append(state.GetAttribute(ctx, path.Root(a.name), &v)...)
+		if diags.HasError() {
+			return diags
+		}
+		diags.Append(identity.SetAttribute(ctx, path.Root(a.name), v)...)
+	}
+	return diags
+}
@@ -15,6 +15,7 @@ import (
 
 var _ resource.Resource = &JobSchedulesResource{}
 var _ resource.ResourceWithImportState = &JobSchedulesResource{}
+var _ resource.ResourceWithUpgradeState = &JobSchedulesResource{}
 
 type JobSchedulesResource struct {
 	client *sdkclient.Client
@@ -30,6 +31,7 @@ func (r *JobSchedulesResource) Metadata(_ context.Context, req resource.Metadata
 
 func (r *JobSchedulesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = resourceschema.Schema{
+		Version:     1,
 		Description: "Manages cron schedules for automated background jobs in an environment. All intervals use cron format.",
 		Attributes: map[string]resourceschema.Attribute{
 			"id": resourceschema.StringAttribute{
@@ -73,6 +75,12 @@ func (r *JobSchedulesResource) Schema(_ context.Context, _ resource.SchemaReques
 	}
 }
 
+func (r *JobSchedulesResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: passthroughStateUpgrader(),
+	}
+}
+
 func (r *JobSchedulesResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData != nil {
 		if c, ok := req.ProviderData.(*sdkclient.Client); ok {
@@ -6,7 +6,6 @@ import (
 
 	"terraform-provider-arcane/internal/sdkclient"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -16,6 +15,8 @@ import (
 
 var _ resource.Resource = &NetworkResource{}
 var _ resource.ResourceWithImportState = &NetworkResource{}
+var _ resource.ResourceWithIdentity = &NetworkResource{}
+var _ resource.ResourceWithUpgradeState = &NetworkResource{}
 
 type NetworkResource struct {
 	client *sdkclient.Client
@@ -31,6 +32,7 @@ func (r *NetworkResource) Metadata(_ context.Context, req resource.MetadataReque
 
 func (r *NetworkResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = resourceschema.Schema{
+		Version:     1,
 		Description: "Manages a Docker network for container communication.",
 		Attributes: map[string]resourceschema.Attribute{
 			"id": resourceschema.StringAttribute{
@@ -104,6 +106,18 @@ func (r *NetworkResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 	}
 }
 
+func (r *NetworkResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: passthroughStateUpgrader(),
+	}
+}
+
+var networkIdentity = envScopedIdentity("/", identityAttr{name: "id", description: "Network ID"})
+
+func (r *NetworkResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = networkIdentity.Schema()
+}
+
 func (r *NetworkResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData != nil {
 		if c, ok := req.ProviderData.(*sdkclient.Client); ok {
@@ -205,6 +219,7 @@ func (r *NetworkResource) Create(ctx context.Context, req resource.CreateRequest
 	state.Ingress = plan.Ingress
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(networkIdentity.Sync(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *NetworkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -233,6 +248,7 @@ func (r *NetworkResource) Read(ctx context.Context, req resource.ReadRequest, re
 	state.Created = types.StringValue(network.Created)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(networkIdentity.Sync(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *NetworkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -256,13 +272,5 @@ func (r *NetworkResource) Delete(ctx context.Context, req resource.DeleteRequest
 }
 
 func (r *NetworkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import format: environment_id/network_id
-	parts := strings.SplitN(req.ID, "/", 2)
-	if len(parts) != 2 {
-		resp.Diagnostics.AddError("invalid import ID", "Expected format: environment_id/network_id")
-		return
-	}
-
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment_id"), parts[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+	networkIdentity.ImportState(ctx, req, resp)
 }
@@ -18,6 +18,8 @@ import (
 
 var _ resource.Resource = &NotificationResource{}
 var _ resource.ResourceWithImportState = &NotificationResource{}
+var _ resource.ResourceWithIdentity = &NotificationResource{}
+var _ resource.ResourceWithUpgradeState = &NotificationResource{}
 
 type NotificationResource struct{ client *sdkclient.Client }
 
@@ -29,6 +31,7 @@ func (r *NotificationResource) Metadata(_ context.Context, req resource.Metadata
 
 func (r *NotificationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = resourceschema.Schema{
+		Version: 1,
 		Attributes: map[string]resourceschema.Attribute{
 			"id":             resourceschema.StringAttribute{Computed: true, PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
 			"environment_id": resourceschema.StringAttribute{Required: true, Description: "Environment ID"},
@@ -39,6 +42,18 @@ func (r *NotificationResource) Schema(_ context.Context, _ resource.SchemaReques
 	}
 }
 
+func (r *NotificationResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: passthroughStateUpgrader(),
+	}
+}
+
+var notificationIdentity = envScopedIdentity(":", identityAttr{name: "provider_name", description: "Notification provider name"})
+
+func (r *NotificationResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = notificationIdentity.Schema()
+}
+
 func (r *NotificationResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData != nil {
 		if c, ok := req.ProviderData.(*sdkclient.Client); ok {
@@ -81,6 +96,7 @@ func (r *NotificationResource) Create(ctx context.Context, req resource.CreateRe
 		Config:        anyMapToStringMap(ctx, out.Config),
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(notificationIdentity.Sync(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *NotificationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -104,6 +120,7 @@ func (r *NotificationResource) Read(ctx context.Context, req resource.ReadReques
 	state.Config = anyMapToStringMap(ctx, out.Config)
 	state.ID = types.StringValue(envID + ":" + provider)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(notificationIdentity.Sync(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *NotificationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -129,6 +146,7 @@ func (r *NotificationResource) Update(ctx context.Context, req resource.UpdateRe
 	state.Enabled = types.BoolValue(out.Enabled)
 	state.Config = anyMapToStringMap(ctx, out.Config)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(notificationIdentity.Sync(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *NotificationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -146,15 +164,15 @@ func (r *NotificationResource) Delete(ctx context.Context, req resource.DeleteRe
 }
 
 func (r *NotificationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// envID:provider
-	parts := strings.SplitN(req.ID, ":", 2)
-	if len(parts) != 2 {
-		resp.Diagnostics.AddError("invalid import id", "expected env_id:provider")
+	notificationIdentity.ImportState(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment_id"), parts[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("provider_name"), parts[1])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	// The state ID is always envID:provider, regardless of how the import was addressed
+	var envID, providerName types.String
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("environment_id"), &envID)...)
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("provider_name"), &providerName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), envID.ValueString()+":"+providerName.ValueString())...)
 }
 
 // map helpers (string<->any)
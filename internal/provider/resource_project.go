@@ -2,21 +2,34 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"strings"
+	"time"
 
 	"terraform-provider-arcane/internal/sdkclient"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var _ resource.Resource = &ProjectResource{}
 var _ resource.ResourceWithImportState = &ProjectResource{}
+var _ resource.ResourceWithIdentity = &ProjectResource{}
+var _ resource.ResourceWithUpgradeState = &ProjectResource{}
+var _ resource.ResourceWithValidateConfig = &ProjectResource{}
+var _ resource.ResourceWithModifyPlan = &ProjectResource{}
 
 type ProjectResource struct{ client *sdkclient.Client }
 
@@ -26,37 +39,120 @@ func (r *ProjectResource) Metadata(_ context.Context, req resource.MetadataReque
 	resp.TypeName = req.ProviderTypeName + "_project"
 }
 
-func (r *ProjectResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *ProjectResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = resourceschema.Schema{
+		Version: 1,
 		Attributes: map[string]resourceschema.Attribute{
 			"id": resourceschema.StringAttribute{
 				Computed:      true,
 				Description:   "Project ID",
 				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
-			"environment_id":     resourceschema.StringAttribute{Required: true, Description: "Environment ID"},
-			"name":               resourceschema.StringAttribute{Required: true, Description: "Project name"},
-			"compose_content":    resourceschema.StringAttribute{Required: true, Description: "docker-compose.yml content"},
-			"env_content":        resourceschema.StringAttribute{Optional: true, Description: ".env content"},
-			"running":            resourceschema.BoolAttribute{Optional: true, Description: "If true, ensure project is running (compose up); if false, compose down. If unset, no lifecycle management."},
+			"environment_id":  resourceschema.StringAttribute{Required: true, Description: "Environment ID"},
+			"name":            resourceschema.StringAttribute{Required: true, Description: "Project name"},
+			"compose_content": resourceschema.StringAttribute{Required: true, CustomType: composeType{}, Description: "docker-compose.yml content; validated at plan time and compared semantically, so formatting-only changes produce no diff."},
+			"env_content": resourceschema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: ".env content",
+				Validators:  []validator.String{stringvalidator.ConflictsWith(path.MatchRoot("env"), path.MatchRoot("sensitive_env"))},
+			},
+			"env": resourceschema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Environment variables rendered to the project's .env file. Conflicts with env_content.",
+				Validators:  []validator.Map{mapvalidator.KeysAre(stringvalidator.RegexMatches(envKeyPattern, "must be a valid variable name"))},
+			},
+			"sensitive_env": resourceschema.MapAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				ElementType: types.StringType,
+				Description: "Like env, but values are hidden from plan output. Keys must not also appear in env.",
+				Validators:  []validator.Map{mapvalidator.KeysAre(stringvalidator.RegexMatches(envKeyPattern, "must be a valid variable name"))},
+			},
+			"compose_overrides": resourceschema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Override documents applied in order on top of compose_content (like docker-compose.override.yml); the merged document is deployed.",
+			},
+			"service_images": resourceschema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Image reference per compose service, replacing that service's image: at deploy time.",
+			},
+			"pin_digests": resourceschema.BoolAttribute{Optional: true, Description: "Resolve every service image tag to its current digest at plan time and deploy the pinned reference."},
+			"resolved_images": resourceschema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Image reference deployed per overridden or pinned service; changes when an upstream tag moves.",
+			},
+			"include_files": resourceschema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Compose include files to manage, keyed by path relative to the project directory. Changes trigger a redeploy.",
+			},
+			"running": resourceschema.BoolAttribute{Optional: true, Description: "If true, ensure project is running (compose up); if false, compose down. If unset, no lifecycle management."},
+			"running_drift": resourceschema.StringAttribute{
+				Optional:    true,
+				Description: "How refresh handles a project whose actual state differs from running: \"reconcile\" (default) records the actual state so apply starts or stops it again; \"warn\" only emits a warning.",
+				Validators:  []validator.String{stringvalidator.OneOf(runningDriftReconcile, runningDriftWarn)},
+			},
 			"redeploy_on_update": resourceschema.BoolAttribute{Optional: true, Computed: true, Description: "Redeploy the project after updating compose/env content.", Default: booldefault.StaticBool(true)},
 			"pull_on_update":     resourceschema.BoolAttribute{Optional: true, Computed: true, Description: "Pull images before redeploy when compose/env changes.", Default: booldefault.StaticBool(false)},
 
+			// Health gating after up/redeploy
+			"rollback_on_failure":       resourceschema.BoolAttribute{Optional: true, Description: "If a redeploy (or the wait_for_healthy check after it) fails, restore the previous compose/env content and redeploy it."},
+			"wait_for_healthy":          resourceschema.BoolAttribute{Optional: true, Description: "After starting or redeploying, wait until all services are running and healthy; fail the apply otherwise."},
+			"wait_for_healthy_timeout":  resourceschema.StringAttribute{Optional: true, Description: "How long to wait for the project to become healthy (default 5m).", Validators: []validator.String{durationValidator{}}},
+			"wait_for_healthy_interval": resourceschema.StringAttribute{Optional: true, Description: "Interval between project status polls while waiting (default 5s).", Validators: []validator.String{durationValidator{}}},
+
 			// Computed fields
 			"path":          resourceschema.StringAttribute{Computed: true, PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
 			"status":        resourceschema.StringAttribute{Computed: true},
 			"service_count": resourceschema.Int64Attribute{Computed: true},
 			"running_count": resourceschema.Int64Attribute{Computed: true},
-			"created_at":    resourceschema.StringAttribute{Computed: true, PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
-			"updated_at":    resourceschema.StringAttribute{Computed: true, PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
+			"services":      projectServicesResourceAttribute(),
+			"revision": resourceschema.Int64Attribute{
+				Computed:      true,
+				Description:   "Deployment revision; increases by one on every successful redeploy of changed content.",
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			"deployed_at": resourceschema.StringAttribute{
+				Computed:      true,
+				Description:   "RFC3339 time the current revision was deployed.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"created_at": resourceschema.StringAttribute{Computed: true, PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
+			"updated_at": resourceschema.StringAttribute{Computed: true, PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
 
 			// Delete options
 			"remove_files":   resourceschema.BoolAttribute{Optional: true, Description: "Remove files on destroy"},
 			"remove_volumes": resourceschema.BoolAttribute{Optional: true, Description: "Remove volumes on destroy"},
 		},
+		Blocks: map[string]resourceschema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Update: true, Delete: true}),
+		},
 	}
 }
 
+func (r *ProjectResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: passthroughStateUpgrader(),
+	}
+}
+
+// Values of running_drift.
+const (
+	runningDriftReconcile = "reconcile"
+	runningDriftWarn      = "warn"
+)
+
+var projectIdentity = envScopedIdentity(":", identityAttr{name: "id", description: "Project ID"})
+
+func (r *ProjectResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = projectIdentity.Schema()
+}
+
 func (r *ProjectResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData != nil {
 		if c, ok := req.ProviderData.(*sdkclient.Client); ok {
@@ -69,19 +165,109 @@ type projectModel struct {
 	ID               types.String `tfsdk:"id"`
 	EnvironmentID    types.String `tfsdk:"environment_id"`
 	Name             types.String `tfsdk:"name"`
-	Compose          types.String `tfsdk:"compose_content"`
+	Compose          composeValue `tfsdk:"compose_content"`
+	Overrides        types.List   `tfsdk:"compose_overrides"`
+	ServiceImages    types.Map    `tfsdk:"service_images"`
+	PinDigests       types.Bool   `tfsdk:"pin_digests"`
+	ResolvedImages   types.Map    `tfsdk:"resolved_images"`
 	Env              types.String `tfsdk:"env_content"`
+	EnvVars          types.Map    `tfsdk:"env"`
+	SensitiveEnv     types.Map    `tfsdk:"sensitive_env"`
+	IncludeFiles     types.Map    `tfsdk:"include_files"`
 	Running          types.Bool   `tfsdk:"running"`
+	RunningDrift     types.String `tfsdk:"running_drift"`
 	RedeployOnUpdate types.Bool   `tfsdk:"redeploy_on_update"`
 	PullOnUpdate     types.Bool   `tfsdk:"pull_on_update"`
+	RollbackOnFail   types.Bool   `tfsdk:"rollback_on_failure"`
+	WaitForHealthy   types.Bool   `tfsdk:"wait_for_healthy"`
+	WaitTimeout      types.String `tfsdk:"wait_for_healthy_timeout"`
+	WaitInterval     types.String `tfsdk:"wait_for_healthy_interval"`
 	Path             types.String `tfsdk:"path"`
 	Status           types.String `tfsdk:"status"`
 	ServiceCount     types.Int64  `tfsdk:"service_count"`
 	RunningCount     types.Int64  `tfsdk:"running_count"`
+	Services         types.List   `tfsdk:"services"`
+	Revision         types.Int64  `tfsdk:"revision"`
+	DeployedAt       types.String `tfsdk:"deployed_at"`
 	CreatedAt        types.String `tfsdk:"created_at"`
 	UpdatedAt        types.String `tfsdk:"updated_at"`
 	RemoveFiles      types.Bool   `tfsdk:"remove_files"`
 	RemoveVolumes    types.Bool   `tfsdk:"remove_volumes"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// ValidateConfig parses compose_content so YAML and compose schema errors are reported with line numbers before apply.
+func (r *ProjectResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var cfg projectModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &cfg)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !cfg.Compose.IsNull() && !cfg.Compose.IsUnknown() {
+		for _, issue := range validateCompose(cfg.Compose.ValueString()) {
+			resp.Diagnostics.AddAttributeError(path.Root("compose_content"), "invalid compose_content", issue.String())
+		}
+	}
+	if !cfg.Overrides.IsNull() && !cfg.Overrides.IsUnknown() {
+		for i, o := range cfg.Overrides.Elements() {
+			doc, ok := o.(types.String)
+			if !ok || doc.IsNull() || doc.IsUnknown() {
+				continue
+			}
+			for _, issue := range validateComposeOverride(doc.ValueString()) {
+				resp.Diagnostics.AddAttributeError(path.Root("compose_overrides").AtListIndex(i), "invalid compose override", issue.String())
+			}
+		}
+	}
+	if !cfg.IncludeFiles.IsNull() && !cfg.IncludeFiles.IsUnknown() {
+		for p := range cfg.IncludeFiles.Elements() {
+			if err := checkIncludePath(p); err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("include_files").AtMapKey(p), "invalid include file path", err.Error())
+			}
+		}
+	}
+	if !cfg.EnvVars.IsNull() && !cfg.EnvVars.IsUnknown() && !cfg.SensitiveEnv.IsNull() && !cfg.SensitiveEnv.IsUnknown() {
+		plain := cfg.EnvVars.Elements()
+		for k := range cfg.SensitiveEnv.Elements() {
+			if _, dup := plain[k]; dup {
+				resp.Diagnostics.AddAttributeError(path.Root("sensitive_env").AtMapKey(k), "duplicate env variable", k+" is set in both env and sensitive_env")
+			}
+		}
+	}
+}
+
+// ModifyPlan resolves the images to deploy and marks revision and deployed_at
+// as changing when the planned content differs from state or a project that
+// was never deployed is started.
+func (r *ProjectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var plan projectModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resolved, diags := r.resolveImages(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.ResolvedImages = resolved
+	if !req.State.Raw.IsNull() {
+		var state projectModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		starting := state.Revision.IsNull() && plan.Running.ValueBool() && !state.Running.ValueBool()
+		if projectContentChanged(plan, state) || starting {
+			plan.Revision = types.Int64Unknown()
+			plan.DeployedAt = types.StringUnknown()
+		}
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
 }
 
 func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -90,12 +276,33 @@ func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts.Create)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	body := sdkclient.ProjectCreateRequest{Name: plan.Name.ValueString(), ComposeContent: plan.Compose.ValueString()}
-	if !plan.Env.IsNull() && !plan.Env.IsUnknown() {
-		v := plan.Env.ValueString()
-		body.EnvContent = &v
+	resp.Diagnostics.Append(r.resolvePlannedImages(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	// Nothing is deployed until the project is started, so revision stays null
+	// unless running = true.
+	plan.Revision = types.Int64Null()
+	plan.DeployedAt = types.StringNull()
+	compose, err := projectComposeContent(ctx, plan)
+	if err != nil {
+		resp.Diagnostics.AddError("build compose document failed", err.Error())
+		return
+	}
+	body := sdkclient.ProjectCreateRequest{Name: plan.Name.ValueString(), ComposeContent: compose}
+	envContent, diags := projectEnvContent(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	body.EnvContent = envContent
 
 	envID := plan.EnvironmentID.ValueString()
 	out, err := r.client.CreateProject(ctx, envID, body)
@@ -103,11 +310,22 @@ func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest
 		resp.Diagnostics.AddError("create project failed", err.Error())
 		return
 	}
+	if err := writeProjectIncludes(ctx, r.client, envID, out.ID, mapFromStringMap(ctx, plan.IncludeFiles), nil); err != nil {
+		resp.Diagnostics.AddError("write project include files failed", err.Error())
+		return
+	}
 
 	// Manage lifecycle if requested
+	var latest *sdkclient.ProjectDetails
 	if !plan.Running.IsNull() && !plan.Running.IsUnknown() {
 		if plan.Running.ValueBool() {
+			plan.Revision = types.Int64Value(1)
+			plan.DeployedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
 			if err := r.client.UpProject(ctx, envID, out.ID); err != nil {
+				plan.ID, plan.Name = types.StringValue(out.ID), types.StringValue(out.Name)
+				if rerr := recordProjectRevision(ctx, r.client, plan, compose, envContent, revisionFailed, err.Error()); rerr != nil {
+					resp.Diagnostics.AddWarning("record project revision failed", rerr.Error())
+				}
 				resp.Diagnostics.AddError("project up failed", err.Error())
 				return
 			}
@@ -122,28 +340,81 @@ func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest
 			out.RunningCount = det.RunningCount
 			out.ServiceCount = det.ServiceCount
 			out.UpdatedAt = det.UpdatedAt
+			latest = det
 		}
 	}
 
+	// The project exists at this point, so a failed health wait still records it
+	// in state (Terraform marks it tainted) instead of orphaning it.
+	var healthErr error
+	if plan.WaitForHealthy.ValueBool() && plan.Running.ValueBool() {
+		det, err := waitForProjectHealthy(ctx, r.client, envID, out.ID, plan.WaitTimeout, plan.WaitInterval)
+		if det != nil {
+			out.Status = det.Status
+			out.RunningCount = det.RunningCount
+			out.ServiceCount = det.ServiceCount
+			latest = det
+		}
+		healthErr = err
+	}
+	if latest == nil {
+		latest, _ = r.client.GetProject(ctx, envID, out.ID)
+	}
+	services := types.ListNull(projectServiceType)
+	if latest != nil {
+		var d diag.Diagnostics
+		services, d = projectServicesValue(ctx, latest.RuntimeServices)
+		resp.Diagnostics.Append(d...)
+	}
+
 	state := projectModel{
 		ID:               types.StringValue(out.ID),
 		EnvironmentID:    plan.EnvironmentID,
 		Name:             types.StringValue(out.Name),
 		Compose:          plan.Compose,
 		Env:              plan.Env,
+		EnvVars:          plan.EnvVars,
+		SensitiveEnv:     plan.SensitiveEnv,
+		Overrides:        plan.Overrides,
+		ServiceImages:    plan.ServiceImages,
+		PinDigests:       plan.PinDigests,
+		ResolvedImages:   resolvedImagesState(plan),
+		IncludeFiles:     plan.IncludeFiles,
 		Path:             types.StringValue(out.Path),
 		Status:           types.StringValue(out.Status),
 		ServiceCount:     types.Int64Value(int64(out.ServiceCount)),
 		RunningCount:     types.Int64Value(int64(out.RunningCount)),
+		Services:         services,
+		Revision:         plan.Revision,
+		DeployedAt:       plan.DeployedAt,
 		CreatedAt:        types.StringValue(out.CreatedAt),
 		UpdatedAt:        types.StringValue(out.UpdatedAt),
 		RemoveFiles:      plan.RemoveFiles,
 		RemoveVolumes:    plan.RemoveVolumes,
 		Running:          plan.Running,
+		RunningDrift:     plan.RunningDrift,
 		RedeployOnUpdate: plan.RedeployOnUpdate,
 		PullOnUpdate:     plan.PullOnUpdate,
+		RollbackOnFail:   plan.RollbackOnFail,
+		WaitForHealthy:   plan.WaitForHealthy,
+		WaitTimeout:      plan.WaitTimeout,
+		WaitInterval:     plan.WaitInterval,
+		Timeouts:         plan.Timeouts,
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(projectIdentity.Sync(ctx, resp.State, resp.Identity)...)
+	if healthErr != nil {
+		if rerr := recordProjectRevision(ctx, r.client, state, compose, envContent, revisionUnhealthy, healthErr.Error()); rerr != nil {
+			resp.Diagnostics.AddWarning("record project revision failed", rerr.Error())
+		}
+		resp.Diagnostics.AddError("project not healthy", healthErr.Error())
+		return
+	}
+	if !state.Revision.IsNull() {
+		if rerr := recordProjectRevision(ctx, r.client, state, compose, envContent, revisionDeployed, ""); rerr != nil {
+			resp.Diagnostics.AddWarning("record project revision failed", rerr.Error())
+		}
+	}
 }
 
 func (r *ProjectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -170,17 +441,64 @@ func (r *ProjectResource) Read(ctx context.Context, req resource.ReadRequest, re
 	state.Status = types.StringValue(out.Status)
 	state.ServiceCount = types.Int64Value(int64(out.ServiceCount))
 	state.RunningCount = types.Int64Value(int64(out.RunningCount))
+	services, diags := projectServicesValue(ctx, out.RuntimeServices)
+	resp.Diagnostics.Append(diags...)
+	state.Services = services
+	// Reconcile the managed lifecycle with reality so a stack stopped or started
+	// outside Terraform shows up as a diff on running.
+	if !state.Running.IsNull() && !state.Running.IsUnknown() {
+		actual := projectRunningState(out, state.Running.ValueBool())
+		if actual != state.Running.ValueBool() {
+			if state.RunningDrift.ValueString() == runningDriftWarn {
+				resp.Diagnostics.AddWarning("project running state drifted",
+					fmt.Sprintf("project %q is expected to be running=%t but has %d/%d services running (status %q)", out.Name, state.Running.ValueBool(), out.RunningCount, out.ServiceCount, out.Status))
+			} else {
+				state.Running = types.BoolValue(actual)
+			}
+		}
+	}
 	// Leave created_at and updated_at unchanged to avoid plan inconsistency on server-side timestamp changes
+	// With overrides or image overrides the server holds the merged document; only record it
+	// when it no longer matches what compose_content, compose_overrides and resolved_images produce.
 	if out.ComposeContent != nil {
-		state.Compose = types.StringValue(*out.ComposeContent)
+		remote := newComposeValue(*out.ComposeContent)
+		if state.Overrides.IsNull() && len(projectImages(ctx, state)) == 0 {
+			state.Compose = remote
+		} else if merged, err := projectComposeContent(ctx, state); err != nil {
+			state.Compose = remote
+		} else if same, _ := remote.StringSemanticEquals(ctx, newComposeValue(merged)); !same {
+			state.Compose = remote
+		}
 	}
-	if out.EnvContent != nil {
+	if !state.EnvVars.IsNull() || !state.SensitiveEnv.IsNull() {
+		content := ""
+		if out.EnvContent != nil {
+			content = *out.EnvContent
+		}
+		envVars, sensitiveEnv, diags := splitEnvContent(ctx, content, state)
+		resp.Diagnostics.Append(diags...)
+		state.EnvVars, state.SensitiveEnv = envVars, sensitiveEnv
+	} else if out.EnvContent != nil {
 		state.Env = types.StringValue(*out.EnvContent)
 	}
+	// Only managed include files are tracked; a managed file missing on the server drops out of the map.
+	if !state.IncludeFiles.IsNull() {
+		remote := serverIncludeFiles(out)
+		files := map[string]string{}
+		for p := range mapFromStringMap(ctx, state.IncludeFiles) {
+			if content, ok := remote[p]; ok {
+				files[p] = content
+			}
+		}
+		includeFiles, diags := types.MapValueFrom(ctx, types.StringType, files)
+		resp.Diagnostics.Append(diags...)
+		state.IncludeFiles = includeFiles
+	}
 	// Preserve configuration values that have defaults
 	// PullOnUpdate, RedeployOnUpdate, Running, RemoveFiles, RemoveVolumes are already in state
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(projectIdentity.Sync(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *ProjectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -191,18 +509,55 @@ func (r *ProjectResource) Update(ctx context.Context, req resource.UpdateRequest
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts.Update)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.resolvePlannedImages(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	envID := state.EnvironmentID.ValueString()
 	projID := state.ID.ValueString()
+	prior := state
+
+	// Redeploy if compose/env changed and enabled (default true) and desired running true/unspecified.
+	// Other updates (e.g. running reconciled after drift) must not redeploy the stack.
+	changedContent := projectContentChanged(plan, state)
+	redeploy := true
+	if !plan.RedeployOnUpdate.IsNull() && !plan.RedeployOnUpdate.IsUnknown() {
+		redeploy = plan.RedeployOnUpdate.ValueBool()
+	}
+	runningDesired := true
+	if !plan.Running.IsNull() && !plan.Running.IsUnknown() {
+		runningDesired = plan.Running.ValueBool()
+	}
+	willRedeploy := changedContent && redeploy && runningDesired
+	plan.Revision, plan.DeployedAt = state.Revision, state.DeployedAt
+	if willRedeploy {
+		plan.Revision = nextRevision(state)
+		plan.DeployedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	}
+
 	body := sdkclient.ProjectUpdateRequest{}
 	if !plan.Compose.IsNull() && !plan.Compose.IsUnknown() {
-		v := plan.Compose.ValueString()
+		v, err := projectComposeContent(ctx, plan)
+		if err != nil {
+			resp.Diagnostics.AddError("build compose document failed", err.Error())
+			return
+		}
 		body.ComposeContent = &v
 	}
-	if !plan.Env.IsNull() && !plan.Env.IsUnknown() {
-		v := plan.Env.ValueString()
-		body.EnvContent = &v
+	envContent, diags := projectEnvContent(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	body.EnvContent = envContent
 	if !plan.Name.IsNull() && !plan.Name.IsUnknown() {
 		v := plan.Name.ValueString()
 		body.Name = &v
@@ -213,9 +568,12 @@ func (r *ProjectResource) Update(ctx context.Context, req resource.UpdateRequest
 		resp.Diagnostics.AddError("update project failed", err.Error())
 		return
 	}
+	if err := writeProjectIncludes(ctx, r.client, envID, projID, mapFromStringMap(ctx, plan.IncludeFiles), mapFromStringMap(ctx, state.IncludeFiles)); err != nil {
+		resp.Diagnostics.AddError("write project include files failed", err.Error())
+		return
+	}
 
-	// Redeploy if compose/env changed and enabled (default true) and desired running true/unspecified
-	changedContent := (body.ComposeContent != nil) || (body.EnvContent != nil)
+	started, redeployed := false, false
 	if changedContent {
 		// Optionally pull first
 		pull := false
@@ -229,19 +587,23 @@ func (r *ProjectResource) Update(ctx context.Context, req resource.UpdateRequest
 			}
 		}
 
-		redeploy := true
-		if !plan.RedeployOnUpdate.IsNull() && !plan.RedeployOnUpdate.IsUnknown() {
-			redeploy = plan.RedeployOnUpdate.ValueBool()
-		}
-		runningDesired := true
-		if !plan.Running.IsNull() && !plan.Running.IsUnknown() {
-			runningDesired = plan.Running.ValueBool()
-		}
-		if redeploy && runningDesired {
+		if willRedeploy {
 			if err := r.client.RedeployProject(ctx, envID, projID); err != nil {
-				resp.Diagnostics.AddError("project redeploy failed", err.Error())
+				detail, outcome := err.Error(), revisionFailed
+				if plan.RollbackOnFail.ValueBool() {
+					var restored bool
+					if restored, detail = r.rollback(ctx, envID, projID, prior, plan, err); restored {
+						outcome = revisionRolledBack
+					}
+				}
+				if rerr := recordProjectRevision(ctx, r.client, plan, *body.ComposeContent, body.EnvContent, outcome, detail); rerr != nil {
+					resp.Diagnostics.AddWarning("record project revision failed", rerr.Error())
+				}
+				// Prior state is kept, which matches the server after a successful rollback.
+				resp.Diagnostics.AddError("project redeploy failed", detail)
 				return
 			}
+			started, redeployed = true, true
 			if det, derr := r.client.GetProject(ctx, envID, projID); derr == nil {
 				out.Status = det.Status
 			}
@@ -258,6 +620,12 @@ func (r *ProjectResource) Update(ctx context.Context, req resource.UpdateRequest
 					resp.Diagnostics.AddError("project up failed", err.Error())
 					return
 				}
+				started = true
+				// Starting a project that was created stopped deploys its first revision.
+				if plan.Revision.IsNull() {
+					plan.Revision = nextRevision(state)
+					plan.DeployedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+				}
 			} else {
 				if err := r.client.DownProject(ctx, envID, projID); err != nil {
 					resp.Diagnostics.AddError("project down failed", err.Error())
@@ -279,10 +647,139 @@ func (r *ProjectResource) Update(ctx context.Context, req resource.UpdateRequest
 	// Leave created_at and updated_at unchanged to avoid plan inconsistency
 	state.Compose = plan.Compose
 	state.Env = plan.Env
+	state.EnvVars = plan.EnvVars
+	state.SensitiveEnv = plan.SensitiveEnv
+	state.Overrides = plan.Overrides
+	state.ServiceImages = plan.ServiceImages
+	state.PinDigests = plan.PinDigests
+	state.ResolvedImages = resolvedImagesState(plan)
+	state.IncludeFiles = plan.IncludeFiles
+	state.Revision = plan.Revision
+	state.DeployedAt = plan.DeployedAt
 	state.PullOnUpdate = plan.PullOnUpdate
 	state.RedeployOnUpdate = plan.RedeployOnUpdate
+	state.RunningDrift = plan.RunningDrift
+	state.WaitForHealthy = plan.WaitForHealthy
+	state.WaitTimeout = plan.WaitTimeout
+	state.WaitInterval = plan.WaitInterval
+	state.RollbackOnFail = plan.RollbackOnFail
+	state.Timeouts = plan.Timeouts
+
+	var healthErr error
+	if started && plan.WaitForHealthy.ValueBool() {
+		det, err := waitForProjectHealthy(ctx, r.client, envID, projID, plan.WaitTimeout, plan.WaitInterval)
+		if det != nil {
+			state.Status = types.StringValue(det.Status)
+			state.ServiceCount = types.Int64Value(int64(det.ServiceCount))
+			state.RunningCount = types.Int64Value(int64(det.RunningCount))
+		}
+		healthErr = err
+	}
+	outcome := revisionDeployed
+	if healthErr != nil {
+		outcome = revisionUnhealthy
+	}
+	if healthErr != nil && redeployed && plan.RollbackOnFail.ValueBool() {
+		restored, detail := r.rollback(ctx, envID, projID, prior, plan, healthErr)
+		if restored {
+			outcome = revisionRolledBack
+			state.Revision = prior.Revision
+			state.DeployedAt = prior.DeployedAt
+			state.Compose = prior.Compose
+			state.Env = prior.Env
+			state.EnvVars = prior.EnvVars
+			state.SensitiveEnv = prior.SensitiveEnv
+			state.Overrides = prior.Overrides
+			state.ServiceImages = prior.ServiceImages
+			state.ResolvedImages = prior.ResolvedImages
+			state.IncludeFiles = prior.IncludeFiles
+		}
+		healthErr = errors.New(detail)
+	}
+	if redeployed || (started && prior.Revision.IsNull()) {
+		detail := ""
+		if healthErr != nil {
+			detail = healthErr.Error()
+		}
+		if rerr := recordProjectRevision(ctx, r.client, plan, *body.ComposeContent, body.EnvContent, outcome, detail); rerr != nil {
+			resp.Diagnostics.AddWarning("record project revision failed", rerr.Error())
+		}
+	}
+	latest := out
+	if det, derr := r.client.GetProject(ctx, envID, projID); derr == nil {
+		latest = det
+	}
+	services, diags := projectServicesValue(ctx, latest.RuntimeServices)
+	resp.Diagnostics.Append(diags...)
+	state.Services = services
 	// state.Running is already updated above if changed
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(projectIdentity.Sync(ctx, resp.State, resp.Identity)...)
+	if healthErr != nil {
+		resp.Diagnostics.AddError("project not healthy", healthErr.Error())
+	}
+}
+
+// projectComposeContent returns the compose document to deploy for m: compose_content
+// with compose_overrides merged on top in order, then the image overrides.
+func projectComposeContent(ctx context.Context, m projectModel) (string, error) {
+	merged, err := mergeComposeDocuments(m.Compose.ValueString(), listToStrings(ctx, m.Overrides))
+	if err != nil {
+		return "", err
+	}
+	var docs []string
+	if images := projectImages(ctx, m); len(images) > 0 {
+		doc, err := imageOverrideDocument(images)
+		if err != nil {
+			return "", err
+		}
+		docs = append(docs, doc)
+	}
+	return mergeComposeDocuments(merged, docs)
+}
+
+// projectContentChanged reports whether plan deploys different content than state.
+func projectContentChanged(plan, state projectModel) bool {
+	return !plan.Compose.Equal(state.Compose) || !plan.Env.Equal(state.Env) ||
+		!plan.EnvVars.Equal(state.EnvVars) || !plan.SensitiveEnv.Equal(state.SensitiveEnv) ||
+		!plan.Overrides.Equal(state.Overrides) || !plan.IncludeFiles.Equal(state.IncludeFiles) ||
+		!plan.ServiceImages.Equal(state.ServiceImages) || !plan.ResolvedImages.Equal(state.ResolvedImages)
+}
+
+// resolvedImagesState is the resolved_images value to store after applying m.
+func resolvedImagesState(m projectModel) types.Map {
+	if m.ResolvedImages.IsUnknown() {
+		return types.MapNull(types.StringType)
+	}
+	return m.ResolvedImages
+}
+
+// rollback restores the compose, env and include file content of prev after cause made a redeploy
+// of applied fail and redeploys it. Include files applied added are removed. It reports whether the
+// previous content was written back and returns an error detail describing both the original
+// failure and the rollback.
+func (r *ProjectResource) rollback(ctx context.Context, envID, projID string, prev, applied projectModel, cause error) (bool, string) {
+	// The operation deadline may be what failed the redeploy; give the rollback
+	// its own budget (the provider http_timeout per request).
+	ctx = context.WithoutCancel(ctx)
+	compose, env := prev.Compose.ValueString(), ""
+	if merged, err := projectComposeContent(ctx, prev); err == nil {
+		compose = merged
+	}
+	if content, _ := projectEnvContent(ctx, prev); content != nil {
+		env = *content
+	}
+	body := sdkclient.ProjectUpdateRequest{ComposeContent: &compose, EnvContent: &env}
+	if _, err := r.client.UpdateProject(ctx, envID, projID, body); err != nil {
+		return false, cause.Error() + "\n\nRollback failed: restoring the previous compose/env content: " + err.Error()
+	}
+	if err := writeProjectIncludes(ctx, r.client, envID, projID, mapFromStringMap(ctx, prev.IncludeFiles), mapFromStringMap(ctx, applied.IncludeFiles)); err != nil {
+		return true, cause.Error() + "\n\nRollback failed: restoring the previous include files: " + err.Error()
+	}
+	if err := r.client.RedeployProject(ctx, envID, projID); err != nil {
+		return true, cause.Error() + "\n\nRollback failed: the previous compose/env content was restored but redeploying it failed: " + err.Error()
+	}
+	return true, cause.Error() + "\n\nRolled back: the previous compose/env content was restored and redeployed."
 }
 
 func (r *ProjectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -291,6 +788,12 @@ func (r *ProjectResource) Delete(ctx context.Context, req resource.DeleteRequest
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, diags := withOperationTimeout(ctx, state.Timeouts.Delete)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	envID := state.EnvironmentID.ValueString()
 	projID := state.ID.ValueString()
 	opts := sdkclient.ProjectDestroyOptions{RemoveFiles: state.RemoveFiles.ValueBool(), RemoveVolumes: state.RemoveVolumes.ValueBool()}
@@ -303,12 +806,5 @@ func (r *ProjectResource) Delete(ctx context.Context, req resource.DeleteRequest
 }
 
 func (r *ProjectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import by envID:projectID
-	parts := strings.SplitN(req.ID, ":", 2)
-	if len(parts) != 2 {
-		resp.Diagnostics.AddError("invalid import id", "expected env_id:project_id")
-		return
-	}
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment_id"), parts[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+	projectIdentity.ImportState(ctx, req, resp)
 }
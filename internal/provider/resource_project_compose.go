@@ -0,0 +1,315 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"gopkg.in/yaml.v3"
+)
+
+// composeType is a string type holding a compose document. Two values are
+// semantically equal when they decode to the same YAML data, so reordering keys,
+// changing quoting or editing comments neither shows a diff nor triggers a redeploy.
+type composeType struct{ basetypes.StringType }
+
+var _ basetypes.StringTypable = composeType{}
+
+func (t composeType) Equal(o attr.Type) bool {
+	other, ok := o.(composeType)
+	return ok && t.StringType.Equal(other.StringType)
+}
+
+func (t composeType) String() string { return "composeType" }
+
+func (t composeType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return composeValue{StringValue: in}, nil
+}
+
+func (t composeType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	v, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := v.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type %T", v)
+	}
+	return composeValue{StringValue: s}, nil
+}
+
+func (t composeType) ValueType(_ context.Context) attr.Value { return composeValue{} }
+
+type composeValue struct{ basetypes.StringValue }
+
+var _ basetypes.StringValuableWithSemanticEquals = composeValue{}
+
+func newComposeValue(s string) composeValue {
+	return composeValue{StringValue: basetypes.NewStringValue(s)}
+}
+
+func (v composeValue) Equal(o attr.Value) bool {
+	other, ok := o.(composeValue)
+	return ok && v.StringValue.Equal(other.StringValue)
+}
+
+func (v composeValue) Type(_ context.Context) attr.Type { return composeType{} }
+
+func (v composeValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	other, ok := newValuable.(composeValue)
+	if !ok {
+		return false, diags
+	}
+	var a, b any
+	if err := yaml.Unmarshal([]byte(v.ValueString()), &a); err != nil {
+		return false, diags
+	}
+	if err := yaml.Unmarshal([]byte(other.ValueString()), &b); err != nil {
+		return false, diags
+	}
+	return reflect.DeepEqual(a, b), diags
+}
+
+// composeIssue is a problem found in a compose document; line is 1-based, 0 if unknown.
+type composeIssue struct {
+	line int
+	msg  string
+}
+
+func (i composeIssue) String() string {
+	if i.line == 0 {
+		return i.msg
+	}
+	return "line " + strconv.Itoa(i.line) + ": " + i.msg
+}
+
+var yamlErrLine = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+// composeTopLevelKeys are the top-level keys of the compose specification;
+// x-* extension keys are accepted as well.
+var composeTopLevelKeys = map[string]bool{
+	"version": true, "name": true, "include": true, "services": true,
+	"networks": true, "volumes": true, "configs": true, "secrets": true,
+}
+
+// validateCompose reports YAML syntax errors and basic compose schema violations.
+// It does not resolve variables or include files, so it only rejects documents
+// the server could never accept.
+func validateCompose(content string) []composeIssue {
+	return checkCompose(content, false)
+}
+
+// validateComposeOverride is validateCompose for override documents, which may
+// omit services and define partial services without image or build.
+func validateComposeOverride(content string) []composeIssue {
+	return checkCompose(content, true)
+}
+
+func checkCompose(content string, override bool) []composeIssue {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		var issues []composeIssue
+		for _, line := range strings.Split(strings.TrimPrefix(err.Error(), "yaml: unmarshal errors:\n"), "\n") {
+			line = strings.TrimSpace(line)
+			if m := yamlErrLine.FindStringSubmatch(line); m != nil {
+				n, _ := strconv.Atoi(m[1])
+				issues = append(issues, composeIssue{line: n, msg: m[2]})
+			} else if line != "" {
+				issues = append(issues, composeIssue{msg: strings.TrimPrefix(line, "yaml: ")})
+			}
+		}
+		return issues
+	}
+	if len(doc.Content) == 0 {
+		return []composeIssue{{msg: "compose document is empty"}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return []composeIssue{{line: root.Line, msg: "compose document must be a mapping"}}
+	}
+
+	var issues []composeIssue
+	var services *yaml.Node
+	hasInclude := false
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, val := root.Content[i], root.Content[i+1]
+		switch {
+		case strings.HasPrefix(key.Value, "x-"):
+		case !composeTopLevelKeys[key.Value]:
+			issues = append(issues, composeIssue{line: key.Line, msg: fmt.Sprintf("unknown top-level key %q", key.Value)})
+		case key.Value == "services":
+			services = val
+		case key.Value == "include":
+			hasInclude = true
+		case key.Value == "networks" || key.Value == "volumes" || key.Value == "configs" || key.Value == "secrets":
+			if val.Kind != yaml.MappingNode && !isNullNode(val) {
+				issues = append(issues, composeIssue{line: val.Line, msg: key.Value + " must be a mapping"})
+			}
+		}
+	}
+	switch {
+	case services == nil && !hasInclude && !override:
+		issues = append(issues, composeIssue{line: root.Line, msg: "services is required"})
+	case services != nil && services.Kind != yaml.MappingNode:
+		issues = append(issues, composeIssue{line: services.Line, msg: "services must be a mapping of service name to definition"})
+	case services != nil:
+		for i := 0; i+1 < len(services.Content); i += 2 {
+			name, svc := services.Content[i], services.Content[i+1]
+			if svc.Kind != yaml.MappingNode {
+				issues = append(issues, composeIssue{line: svc.Line, msg: fmt.Sprintf("service %q must be a mapping", name.Value)})
+				continue
+			}
+			if !override && !hasAnyKey(svc, "image", "build", "extends") {
+				issues = append(issues, composeIssue{line: name.Line, msg: fmt.Sprintf("service %q must define image or build", name.Value)})
+			}
+		}
+	}
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].line < issues[j].line })
+	return issues
+}
+
+func isNullNode(n *yaml.Node) bool {
+	return n.Kind == yaml.ScalarNode && n.Tag == "!!null"
+}
+
+func hasAnyKey(m *yaml.Node, keys ...string) bool {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		for _, k := range keys {
+			if m.Content[i].Value == k {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// composeUnionKeys are service sequences that an override extends instead of
+// replacing, following the compose merge rules.
+var composeUnionKeys = map[string]bool{
+	"ports": true, "expose": true, "dns": true, "dns_search": true, "tmpfs": true,
+	"cap_add": true, "cap_drop": true, "devices": true, "external_links": true,
+	"security_opt": true, "volumes": true, "secrets": true, "configs": true, "env_file": true,
+	"extra_hosts": true,
+}
+
+// composeKeyValueKeys are service attributes written either as a mapping or as a
+// KEY=value list; both forms are merged by key.
+var composeKeyValueKeys = map[string]bool{
+	"environment": true, "labels": true, "sysctls": true, "annotations": true,
+}
+
+// mergeComposeDocuments merges override documents into base in order, the way
+// `docker compose -f base -f override ...` does: mappings merge recursively,
+// KEY=value lists merge by key, list attributes such as ports are concatenated
+// without duplicates and any other value is replaced.
+func mergeComposeDocuments(base string, overrides []string) (string, error) {
+	if len(overrides) == 0 {
+		return base, nil
+	}
+	var merged any
+	if err := yaml.Unmarshal([]byte(base), &merged); err != nil {
+		return "", fmt.Errorf("compose document: %w", err)
+	}
+	for i, o := range overrides {
+		var doc any
+		if err := yaml.Unmarshal([]byte(o), &doc); err != nil {
+			return "", fmt.Errorf("override %d: %w", i, err)
+		}
+		merged = mergeComposeValue(nil, merged, doc)
+	}
+	var out strings.Builder
+	enc := yaml.NewEncoder(&out)
+	enc.SetIndent(2)
+	if err := enc.Encode(merged); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// mergeComposeValue merges over into base at path, the keys leading to them.
+// The key-value and union rules apply only to attributes of a service
+// (services.<name>.<attribute>), so a service or top-level volume named like
+// one of those attributes still merges recursively.
+func mergeComposeValue(path []string, base, over any) any {
+	key := ""
+	if len(path) == 3 && path[0] == "services" {
+		key = path[2]
+	}
+	if composeKeyValueKeys[key] {
+		if bm, om := composeKeyValues(base), composeKeyValues(over); bm != nil && om != nil {
+			for k, v := range om {
+				bm[k] = v
+			}
+			return bm
+		}
+	}
+	switch o := over.(type) {
+	case map[string]any:
+		b, ok := base.(map[string]any)
+		if !ok {
+			return o
+		}
+		for k, v := range o {
+			if bv, exists := b[k]; exists {
+				b[k] = mergeComposeValue(append(path[:len(path):len(path)], k), bv, v)
+			} else {
+				b[k] = v
+			}
+		}
+		return b
+	case []any:
+		b, ok := base.([]any)
+		if !ok || !composeUnionKeys[key] {
+			return o
+		}
+		for _, v := range o {
+			dup := false
+			for _, existing := range b {
+				if reflect.DeepEqual(existing, v) {
+					dup = true
+					break
+				}
+			}
+			if !dup {
+				b = append(b, v)
+			}
+		}
+		return b
+	}
+	return over
+}
+
+// composeKeyValues converts a mapping or KEY=value list to a mapping; nil for other shapes.
+func composeKeyValues(v any) map[string]any {
+	switch t := v.(type) {
+	case map[string]any:
+		return t
+	case []any:
+		m := make(map[string]any, len(t))
+		for _, item := range t {
+			s, ok := item.(string)
+			if !ok {
+				return nil
+			}
+			if k, val, found := strings.Cut(s, "="); found {
+				m[k] = val
+			} else {
+				m[s] = nil
+			}
+		}
+		return m
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	envKeyPattern  = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+	envPlainValue  = regexp.MustCompile(`^[A-Za-z0-9_./:@,+=%-]*$`)
+	envDoubleQuote = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`, `$`, `\$`)
+)
+
+// renderEnvFile renders variables as a .env file with one KEY=value line per
+// variable, sorted by key. Values that are not plain words are single-quoted
+// (taken literally by compose) or, if they contain a single quote or line break,
+// double-quoted with backslash escapes.
+func renderEnvFile(vars map[string]string) string {
+	keys := make([]string, 0, len(vars))
+	for k := range vars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	for _, k := range keys {
+		v := vars[k]
+		b.WriteString(k)
+		b.WriteByte('=')
+		switch {
+		case envPlainValue.MatchString(v):
+			b.WriteString(v)
+		case !strings.ContainsAny(v, "'\n\r"):
+			b.WriteString("'" + v + "'")
+		default:
+			b.WriteString(`"` + envDoubleQuote.Replace(v) + `"`)
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// parseEnvFile parses .env content as written by renderEnvFile or by hand:
+// blank lines and # comments are skipped, an "export " prefix is allowed,
+// single-quoted values are literal, double-quoted values understand the escapes
+// renderEnvFile produces, and unquoted values end at " #".
+func parseEnvFile(content string) map[string]string {
+	vars := map[string]string{}
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		k, v, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		k, v = strings.TrimSpace(k), strings.TrimSpace(v)
+		switch {
+		case len(v) >= 2 && v[0] == '\'' && strings.LastIndexByte(v, '\'') > 0:
+			v = v[1:strings.LastIndexByte(v, '\'')]
+		case len(v) >= 2 && v[0] == '"' && strings.LastIndexByte(v, '"') > 0:
+			v = unescapeEnvValue(v[1:strings.LastIndexByte(v, '"')])
+		default:
+			if i := strings.Index(v, " #"); i >= 0 {
+				v = strings.TrimSpace(v[:i])
+			}
+		}
+		vars[k] = v
+	}
+	return vars
+}
+
+func unescapeEnvValue(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// projectEnvContent returns the .env content to send for m: env and sensitive_env
+// rendered together when either is set, otherwise env_content. It returns nil
+// when the project has no env configuration.
+func projectEnvContent(ctx context.Context, m projectModel) (*string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if m.EnvVars.IsNull() && m.SensitiveEnv.IsNull() {
+		if m.Env.IsNull() || m.Env.IsUnknown() {
+			return nil, diags
+		}
+		v := m.Env.ValueString()
+		return &v, diags
+	}
+	vars := map[string]string{}
+	for _, src := range []types.Map{m.EnvVars, m.SensitiveEnv} {
+		if src.IsNull() || src.IsUnknown() {
+			continue
+		}
+		var part map[string]string
+		diags.Append(src.ElementsAs(ctx, &part, false)...)
+		for k, v := range part {
+			vars[k] = v
+		}
+	}
+	content := renderEnvFile(vars)
+	return &content, diags
+}
+
+// splitEnvContent parses server .env content back into the env and sensitive_env
+// maps of prior. Keys previously in sensitive_env stay there; every other key,
+// including ones added outside Terraform, lands in env so drift shows per variable.
+func splitEnvContent(ctx context.Context, content string, prior projectModel) (types.Map, types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	sensitiveKeys := map[string]bool{}
+	if !prior.SensitiveEnv.IsNull() && !prior.SensitiveEnv.IsUnknown() {
+		for k := range prior.SensitiveEnv.Elements() {
+			sensitiveKeys[k] = true
+		}
+	}
+	plain, secret := map[string]string{}, map[string]string{}
+	for k, v := range parseEnvFile(content) {
+		if sensitiveKeys[k] {
+			secret[k] = v
+		} else {
+			plain[k] = v
+		}
+	}
+	toMap := func(vals map[string]string, was types.Map) types.Map {
+		if len(vals) == 0 && was.IsNull() {
+			return types.MapNull(types.StringType)
+		}
+		m, d := types.MapValueFrom(ctx, types.StringType, vals)
+		diags.Append(d...)
+		return m
+	}
+	return toMap(plain, prior.EnvVars), toMap(secret, prior.SensitiveEnv), diags
+}
@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"terraform-provider-arcane/internal/sdkclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	defaultHealthyTimeout  = 5 * time.Minute
+	defaultHealthyInterval = 5 * time.Second
+)
+
+// waitForProjectHealthy polls GetProject until every service is running and no
+// healthcheck reports anything but healthy. timeout and interval are Go duration
+// strings; null values fall back to the defaults above. Errors reading the project
+// (e.g. a 502 while services restart) are retried until the deadline. On timeout
+// the error lists the last observed status of each service and the last error.
+func waitForProjectHealthy(ctx context.Context, c *sdkclient.Client, envID, projID string, timeout, interval types.String) (*sdkclient.ProjectDetails, error) {
+	wait := durationOrDefault(timeout, defaultHealthyTimeout)
+	poll := durationOrDefault(interval, defaultHealthyInterval)
+	ctx, cancel := context.WithTimeout(ctx, wait)
+	defer cancel()
+
+	var last *sdkclient.ProjectDetails
+	var lastErr error
+	for {
+		det, err := c.GetProject(ctx, envID, projID)
+		if err == nil {
+			last, lastErr = det, nil
+			if projectHealthy(det) {
+				return det, nil
+			}
+		} else if ctx.Err() == nil {
+			lastErr = err
+		}
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return last, fmt.Errorf("project did not become healthy within %s:\n%s\nlast error reading the project: %w", wait, projectHealthSummary(last), lastErr)
+			}
+			return last, fmt.Errorf("project did not become healthy within %s:\n%s", wait, projectHealthSummary(last))
+		case <-time.After(poll):
+		}
+	}
+}
+
+func projectHealthy(det *sdkclient.ProjectDetails) bool {
+	if det.RunningCount != det.ServiceCount {
+		return false
+	}
+	for _, s := range det.RuntimeServices {
+		if !strings.EqualFold(s.Status, "running") {
+			return false
+		}
+		if s.Health != nil && *s.Health != "" && !strings.EqualFold(*s.Health, "healthy") {
+			return false
+		}
+	}
+	return true
+}
+
+// projectRunningState reports whether a project counts as running for drift
+// detection. A project expected to run must have every service up, and one
+// expected to be stopped must have none up, so a partial outage or leftover
+// service reads as the opposite of what is expected.
+func projectRunningState(det *sdkclient.ProjectDetails, expected bool) bool {
+	if !expected {
+		return det.RunningCount > 0 || strings.EqualFold(det.Status, "running")
+	}
+	if det.ServiceCount > 0 {
+		return det.RunningCount == det.ServiceCount
+	}
+	return strings.EqualFold(det.Status, "running")
+}
+
+// projectHealthSummary renders one line per service, e.g. "  web: running (unhealthy)".
+func projectHealthSummary(det *sdkclient.ProjectDetails) string {
+	if det == nil {
+		return "  no project status received"
+	}
+	lines := []string{fmt.Sprintf("  %d/%d services running (status %q)", det.RunningCount, det.ServiceCount, det.Status)}
+	services := append([]sdkclient.ProjectRuntimeService(nil), det.RuntimeServices...)
+	sort.Slice(services, func(i, j int) bool { return services[i].Name < services[j].Name })
+	for _, s := range services {
+		line := "  " + s.Name + ": " + s.Status
+		if s.Health != nil && *s.Health != "" {
+			line += " (" + *s.Health + ")"
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// durationOrDefault parses a duration attribute validated by durationValidator.
+func durationOrDefault(v types.String, def time.Duration) time.Duration {
+	if v.IsNull() || v.IsUnknown() {
+		return def
+	}
+	d, err := time.ParseDuration(v.ValueString())
+	if err != nil || d <= 0 {
+		return def
+	}
+	return d
+}
+
+// durationValidator checks a string is a positive Go duration such as "30s" or "5m".
+type durationValidator struct{}
+
+var _ validator.String = durationValidator{}
+
+func (durationValidator) Description(_ context.Context) string {
+	return "value must be a positive duration such as 30s or 5m"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	d, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil || d <= 0 {
+		resp.Diagnostics.AddAttributeError(req.Path, "invalid duration", v.Description(ctx)+", got "+req.ConfigValue.String())
+	}
+}
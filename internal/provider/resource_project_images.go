@@ -0,0 +1,210 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"terraform-provider-arcane/internal/sdkclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v3"
+)
+
+// resolveImages returns the resolved_images value for plan: the image reference
+// per service named in service_images or, with pin_digests, per service with an
+// image. Digests are asked from the registry on every plan, so a tag that moved
+// upstream shows up as a diff and the apply redeploys the new digest.
+func (r *ProjectResource) resolveImages(ctx context.Context, plan projectModel) (types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if plan.ServiceImages.IsNull() && !plan.PinDigests.ValueBool() {
+		return types.MapNull(types.StringType), diags
+	}
+	known := !plan.Compose.IsUnknown() && !plan.PinDigests.IsUnknown() && !plan.EnvironmentID.IsUnknown() &&
+		elementsKnown(plan.Overrides.IsUnknown(), plan.Overrides.Elements()) &&
+		elementsKnown(plan.ServiceImages.IsUnknown(), mapValues(plan.ServiceImages))
+	if !known || (plan.PinDigests.ValueBool() && r.client == nil) {
+		return types.MapUnknown(types.StringType), diags
+	}
+
+	merged, err := mergeComposeDocuments(plan.Compose.ValueString(), listToStrings(ctx, plan.Overrides))
+	if err != nil {
+		// Reported by Create/Update; nothing to resolve against.
+		return types.MapUnknown(types.StringType), diags
+	}
+	images, err := composeServiceImages(merged)
+	if err != nil {
+		return types.MapUnknown(types.StringType), diags
+	}
+	overrides := mapFromStringMap(ctx, plan.ServiceImages)
+	for svc, ref := range overrides {
+		if _, ok := images[svc]; !ok {
+			diags.AddAttributeError(path.Root("service_images").AtMapKey(svc), "unknown service",
+				fmt.Sprintf("service %q is not defined in compose_content or compose_overrides", svc))
+			continue
+		}
+		images[svc] = ref
+	}
+
+	resolved := map[string]string{}
+	for _, svc := range sortedKeys(images) {
+		ref := images[svc]
+		_, overridden := overrides[svc]
+		switch {
+		case ref == "":
+			// Build-only service; nothing to pin.
+			continue
+		case !plan.PinDigests.ValueBool():
+			if !overridden {
+				continue
+			}
+		case strings.Contains(ref, "$"):
+			diags.AddAttributeWarning(path.Root("pin_digests"), "image not pinned",
+				fmt.Sprintf("service %q uses the interpolated image %q, which cannot be resolved at plan time; set it in service_images to pin it", svc, ref))
+		default:
+			pinned, err := pinImageDigest(ctx, r.client, plan.EnvironmentID.ValueString(), ref)
+			if err != nil {
+				diags.AddAttributeError(path.Root("pin_digests"), "resolve image digest failed", fmt.Sprintf("service %q: %s", svc, err))
+				continue
+			}
+			ref = pinned
+		}
+		resolved[svc] = ref
+	}
+	value, d := types.MapValueFrom(ctx, types.StringType, resolved)
+	diags.Append(d...)
+	return value, diags
+}
+
+// resolvePlannedImages resolves resolved_images at apply time when its inputs
+// were unknown at plan time, so the deployed content is pinned as configured.
+func (r *ProjectResource) resolvePlannedImages(ctx context.Context, plan *projectModel) diag.Diagnostics {
+	if !plan.ResolvedImages.IsUnknown() {
+		return nil
+	}
+	resolved, diags := r.resolveImages(ctx, *plan)
+	plan.ResolvedImages = resolved
+	return diags
+}
+
+// projectImages returns the image reference to force per service for m: the
+// planned resolved_images when known, otherwise service_images.
+func projectImages(ctx context.Context, m projectModel) map[string]string {
+	if !m.ResolvedImages.IsNull() && !m.ResolvedImages.IsUnknown() {
+		return mapFromStringMap(ctx, m.ResolvedImages)
+	}
+	return mapFromStringMap(ctx, m.ServiceImages)
+}
+
+// composeServiceImages maps every service of a compose document to its image,
+// "" for services without one.
+func composeServiceImages(content string) (map[string]string, error) {
+	var doc struct {
+		Services map[string]struct {
+			Image string `yaml:"image"`
+		} `yaml:"services"`
+	}
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		return nil, err
+	}
+	images := make(map[string]string, len(doc.Services))
+	for name, svc := range doc.Services {
+		images[name] = svc.Image
+	}
+	return images, nil
+}
+
+// imageOverrideDocument renders an override document setting image: for each service.
+func imageOverrideDocument(images map[string]string) (string, error) {
+	services := make(map[string]map[string]string, len(images))
+	for svc, ref := range images {
+		services[svc] = map[string]string{"image": ref}
+	}
+	b, err := yaml.Marshal(map[string]any{"services": services})
+	return string(b), err
+}
+
+// pinImageDigest returns ref with the digest its tag currently points to appended
+// (repo:tag@sha256:...). The registry is asked through the environment first;
+// if it cannot answer, the digest of the image pulled on the environment is used.
+// References that already carry a digest are returned unchanged.
+func pinImageDigest(ctx context.Context, c *sdkclient.Client, envID, ref string) (string, error) {
+	if strings.Contains(ref, "@") {
+		return ref, nil
+	}
+	info, err := c.CheckImageUpdate(ctx, envID, ref)
+	if err == nil && info.LatestDigest != "" {
+		return ref + "@" + info.LatestDigest, nil
+	}
+	if err == nil && info.Error != "" {
+		err = fmt.Errorf("%s", info.Error)
+	}
+	if digest := localImageDigest(ctx, c, envID, ref); digest != "" {
+		return ref + "@" + digest, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("resolve %s: %w", ref, err)
+	}
+	return "", fmt.Errorf("no digest found for %s", ref)
+}
+
+// localImageDigest returns the repo digest of the image tagged ref on the environment, "" if none.
+func localImageDigest(ctx context.Context, c *sdkclient.Client, envID, ref string) string {
+	repo, tag := ref, "latest"
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		repo, tag = ref[:i], ref[i+1:]
+	}
+	images, err := c.ListImages(ctx, envID)
+	if err != nil {
+		return ""
+	}
+	for _, img := range images {
+		if img.Repo != repo || img.Tag != tag {
+			continue
+		}
+		det, err := c.GetImage(ctx, envID, img.ID)
+		if err != nil {
+			return ""
+		}
+		for _, d := range det.RepoDigests {
+			if _, digest, ok := strings.Cut(d, "@"); ok {
+				return digest
+			}
+		}
+	}
+	return ""
+}
+
+// elementsKnown reports whether a list or map and all of its elements are known.
+func elementsKnown(unknown bool, elems []attr.Value) bool {
+	if unknown {
+		return false
+	}
+	for _, e := range elems {
+		if e.IsUnknown() {
+			return false
+		}
+	}
+	return true
+}
+
+func mapValues(m types.Map) []attr.Value {
+	values := make([]attr.Value, 0, len(m.Elements()))
+	for _, v := range m.Elements() {
+		values = append(values, v)
+	}
+	return values
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"terraform-provider-arcane/internal/sdkclient"
+)
+
+// checkIncludePath rejects include paths that would escape the project directory.
+func checkIncludePath(p string) error {
+	if p == "" || filepath.IsAbs(p) || strings.HasPrefix(p, "/") {
+		return fmt.Errorf("%q must be a relative path", p)
+	}
+	clean := filepath.ToSlash(filepath.Clean(p))
+	if clean == ".." || strings.HasPrefix(clean, "../") {
+		return fmt.Errorf("%q must stay inside the project directory", p)
+	}
+	return nil
+}
+
+// serverIncludeFiles returns the include files of a project keyed by relative path.
+func serverIncludeFiles(det *sdkclient.ProjectDetails) map[string]string {
+	files := make(map[string]string, len(det.IncludeFiles))
+	for _, f := range det.IncludeFiles {
+		files[filepath.ToSlash(filepath.Clean(f.RelativePath))] = f.Content
+	}
+	return files
+}
+
+// writeProjectIncludes uploads every file in files whose content differs from
+// prior and removes the files of prior that are no longer in files.
+func writeProjectIncludes(ctx context.Context, c *sdkclient.Client, envID, projID string, files, prior map[string]string) error {
+	paths := make([]string, 0, len(files))
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		if old, ok := prior[p]; ok && old == files[p] {
+			continue
+		}
+		body := sdkclient.ProjectIncludeUpdateRequest{RelativePath: p, Content: files[p]}
+		if err := c.UpdateProjectInclude(ctx, envID, projID, body); err != nil {
+			return fmt.Errorf("write include file %s: %w", p, err)
+		}
+	}
+	return deleteProjectIncludes(ctx, c, envID, projID, droppedIncludes(files, prior))
+}
+
+// droppedIncludes returns the paths of prior missing from files, sorted.
+func droppedIncludes(files, prior map[string]string) []string {
+	var dropped []string
+	for p := range prior {
+		if _, ok := files[p]; !ok {
+			dropped = append(dropped, p)
+		}
+	}
+	sort.Strings(dropped)
+	return dropped
+}
+
+// deleteProjectIncludes removes include files; files already gone are ignored.
+func deleteProjectIncludes(ctx context.Context, c *sdkclient.Client, envID, projID string, paths []string) error {
+	for _, p := range paths {
+		if err := c.DeleteProjectInclude(ctx, envID, projID, p); err != nil && !strings.Contains(strings.ToLower(err.Error()), "404") {
+			return fmt.Errorf("remove include file %s: %w", p, err)
+		}
+	}
+	return nil
+}
+
+// collectIncludeFiles reads the files matched by glob patterns relative to baseDir.
+// A pattern matching a directory includes every regular file below it. Keys are
+// slash-separated paths relative to baseDir.
+func collectIncludeFiles(baseDir string, patterns []string) (map[string]string, error) {
+	files := map[string]string{}
+	add := func(p string) error {
+		rel, err := filepath.Rel(baseDir, p)
+		if err != nil {
+			return err
+		}
+		if err := checkIncludePath(rel); err != nil {
+			return err
+		}
+		b, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = string(b)
+		return nil
+	}
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(filepath.Join(baseDir, pattern))
+		if err != nil {
+			return nil, fmt.Errorf("pattern %q: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("pattern %q matches no files in %s", pattern, baseDir)
+		}
+		for _, m := range matches {
+			err := filepath.WalkDir(m, func(p string, d fs.DirEntry, err error) error {
+				if err != nil || !d.Type().IsRegular() {
+					return err
+				}
+				return add(p)
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return files, nil
+}
+
+// hashFiles maps each path to the hex SHA256 of its content.
+func hashFiles(files map[string]string) map[string]string {
+	hashes := make(map[string]string, len(files))
+	for p, content := range files {
+		h := sha256.Sum256([]byte(content))
+		hashes[p] = hex.EncodeToString(h[:])
+	}
+	return hashes
+}
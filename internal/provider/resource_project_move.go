@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// arcane_project and arcane_project_path manage the same server-side object, so a
+// moved {} block between them keeps the project ID, environment and lifecycle
+// settings instead of destroying and recreating the stack. Attributes that only
+// exist on the target (compose_path, redeploy_on_update, hashes) are left for the
+// next plan to fill in from configuration.
+
+var _ resource.ResourceWithMoveState = &ProjectResource{}
+var _ resource.ResourceWithMoveState = &ProjectPathResource{}
+
+// sourceSchema returns the current schema of r for use as a StateMover SourceSchema.
+func sourceSchema(ctx context.Context, r resource.Resource) *resourceschema.Schema {
+	var resp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &resp)
+	return &resp.Schema
+}
+
+func (r *ProjectResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{
+			SourceSchema: sourceSchema(ctx, &ProjectPathResource{}),
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				if req.SourceTypeName != "arcane_project_path" || req.SourceState == nil {
+					return
+				}
+				var src projectPathModel
+				resp.Diagnostics.Append(req.SourceState.Get(ctx, &src)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				dst := projectModel{
+					ID:               src.ID,
+					EnvironmentID:    src.EnvironmentID,
+					Name:             src.Name,
+					Compose:          composeValue{StringValue: src.Compose},
+					Overrides:        types.ListNull(types.StringType),
+					ServiceImages:    types.MapNull(types.StringType),
+					ResolvedImages:   types.MapNull(types.StringType),
+					Env:              src.Env,
+					EnvVars:          types.MapNull(types.StringType),
+					SensitiveEnv:     types.MapNull(types.StringType),
+					IncludeFiles:     types.MapNull(types.StringType),
+					Running:          src.Running,
+					RedeployOnUpdate: types.BoolValue(true),
+					PullOnUpdate:     src.PullOnUpdate,
+					Path:             src.Path,
+					Status:           src.Status,
+					ServiceCount:     src.ServiceCount,
+					RunningCount:     src.RunningCount,
+					Services:         types.ListNull(projectServiceType),
+					CreatedAt:        src.CreatedAt,
+					UpdatedAt:        src.UpdatedAt,
+					RemoveFiles:      src.RemoveFiles,
+					RemoveVolumes:    src.RemoveVolumes,
+					Timeouts:         src.Timeouts,
+				}
+				resp.Diagnostics.Append(resp.TargetState.Set(ctx, &dst)...)
+				resp.Diagnostics.Append(projectIdentity.Sync(ctx, resp.TargetState, resp.TargetIdentity)...)
+			},
+		},
+	}
+}
+
+func (r *ProjectPathResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{
+			SourceSchema: sourceSchema(ctx, &ProjectResource{}),
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				if req.SourceTypeName != "arcane_project" || req.SourceState == nil {
+					return
+				}
+				var src projectModel
+				resp.Diagnostics.Append(req.SourceState.Get(ctx, &src)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				dst := projectPathModel{
+					ID:              src.ID,
+					EnvironmentID:   src.EnvironmentID,
+					Name:            src.Name,
+					ComposePath:     types.StringNull(),
+					EnvPath:         types.StringNull(),
+					OverridePaths:   types.ListNull(types.StringType),
+					TemplateVars:    types.MapNull(types.StringType),
+					ContentHashMode: types.BoolNull(),
+					Compose:         src.Compose.StringValue,
+					Env:             src.Env,
+					ComposeHash:     types.StringNull(),
+					EnvHash:         types.StringNull(),
+					IncludePaths:    types.ListNull(types.StringType),
+					IncludeHashes:   types.MapNull(types.StringType),
+					SourceInclude:   types.ListNull(types.StringType),
+					SourceExclude:   types.ListNull(types.StringType),
+					SourceHashes:    types.MapNull(types.StringType),
+					Running:         src.Running,
+					PullOnUpdate:    src.PullOnUpdate,
+					Path:            src.Path,
+					Status:          src.Status,
+					ServiceCount:    src.ServiceCount,
+					RunningCount:    src.RunningCount,
+					CreatedAt:       src.CreatedAt,
+					UpdatedAt:       src.UpdatedAt,
+					RemoveFiles:     src.RemoveFiles,
+					RemoveVolumes:   src.RemoveVolumes,
+					Timeouts:        src.Timeouts,
+				}
+				resp.Diagnostics.Append(resp.TargetState.Set(ctx, &dst)...)
+				resp.Diagnostics.Append(projectPathIdentity.Sync(ctx, resp.TargetState, resp.TargetIdentity)...)
+			},
+		},
+	}
+}
@@ -4,24 +4,30 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
-	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"terraform-provider-arcane/internal/sdkclient"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var _ resource.Resource = &ProjectPathResource{}
 var _ resource.ResourceWithImportState = &ProjectPathResource{}
+var _ resource.ResourceWithIdentity = &ProjectPathResource{}
 var _ resource.ResourceWithModifyPlan = &ProjectPathResource{}
+var _ resource.ResourceWithUpgradeState = &ProjectPathResource{}
+var _ resource.ResourceWithValidateConfig = &ProjectPathResource{}
 
 type ProjectPathResource struct{ client *sdkclient.Client }
 
@@ -31,8 +37,9 @@ func (r *ProjectPathResource) Metadata(_ context.Context, req resource.MetadataR
 	resp.TypeName = req.ProviderTypeName + "_project_path"
 }
 
-func (r *ProjectPathResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *ProjectPathResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = resourceschema.Schema{
+		Version: 1,
 		Attributes: map[string]resourceschema.Attribute{
 			"id":             resourceschema.StringAttribute{Computed: true, Description: "Project ID", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
 			"environment_id": resourceschema.StringAttribute{Required: true, Description: "Environment ID"},
@@ -40,6 +47,10 @@ func (r *ProjectPathResource) Schema(_ context.Context, _ resource.SchemaRequest
 			"compose_path":   resourceschema.StringAttribute{Required: true, Description: "Filesystem path to docker-compose.yml"},
 			"env_path":       resourceschema.StringAttribute{Optional: true, Description: "Filesystem path to .env"},
 
+			"compose_override_paths": resourceschema.ListAttribute{Optional: true, ElementType: types.StringType, Description: "Filesystem paths of override compose files applied in order on top of compose_path; the merged document is deployed."},
+
+			"template_vars": resourceschema.MapAttribute{Optional: true, ElementType: types.StringType, Description: "Variables for rendering the compose, override and env files as Go templates ({{ .name }}) before they are hashed and uploaded."},
+
 			// Controls whether to store full file contents or only hashes in state
 			"content_hash_mode": resourceschema.BoolAttribute{Optional: true, Description: "If true, store only content hashes in state instead of full file contents."},
 
@@ -49,6 +60,21 @@ func (r *ProjectPathResource) Schema(_ context.Context, _ resource.SchemaRequest
 			"compose_content_hash": resourceschema.StringAttribute{Computed: true, Sensitive: true},
 			"env_content_hash":     resourceschema.StringAttribute{Computed: true, Sensitive: true},
 
+			// Compose include files, matched relative to the compose file's directory
+			"include_paths":       resourceschema.ListAttribute{Optional: true, ElementType: types.StringType, Description: "Glob patterns, relative to the directory of compose_path, of include files to upload with the project. A pattern matching a directory uploads every file below it."},
+			"include_file_hashes": resourceschema.MapAttribute{Computed: true, ElementType: types.StringType, Description: "SHA256 of each uploaded include file, keyed by relative path; used to detect changes and drift."},
+
+			// Whole-directory sources uploaded next to the compose file
+			"source_dir": resourceschema.StringAttribute{
+				Optional:    true,
+				Description: "Directory whose files are uploaded to the project directory, keeping their relative paths. Files deleted locally are removed on the server.",
+				Validators:  []validator.String{stringvalidator.ConflictsWith(path.MatchRoot("include_paths"))},
+			},
+			"source_include":     resourceschema.ListAttribute{Optional: true, ElementType: types.StringType, Description: "Glob patterns (*, ?, **) selecting files of source_dir to upload; all files when unset."},
+			"source_exclude":     resourceschema.ListAttribute{Optional: true, ElementType: types.StringType, Description: "Glob patterns of files or directories of source_dir to skip. .git and .terraform are always skipped."},
+			"source_file_hashes": resourceschema.MapAttribute{Computed: true, ElementType: types.StringType, Description: "SHA256 of each uploaded source_dir file, keyed by relative path."},
+			"source_tree_hash":   resourceschema.StringAttribute{Computed: true, Description: "Combined hash of all source_dir files and their paths; changes when any file is added, removed or edited."},
+
 			// Lifecycle (optional)
 			"running":        resourceschema.BoolAttribute{Optional: true, Description: "If true, ensure project is running (compose up); if false, compose down. If unset, no lifecycle management."},
 			"pull_on_update": resourceschema.BoolAttribute{Optional: true, Computed: true, Description: "Pull images before redeploy when compose/env changes.", Default: booldefault.StaticBool(false)},
@@ -65,9 +91,24 @@ func (r *ProjectPathResource) Schema(_ context.Context, _ resource.SchemaRequest
 			"remove_files":   resourceschema.BoolAttribute{Optional: true, Description: "Remove files on destroy"},
 			"remove_volumes": resourceschema.BoolAttribute{Optional: true, Description: "Remove volumes on destroy"},
 		},
+		Blocks: map[string]resourceschema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Update: true, Delete: true}),
+		},
+	}
+}
+
+func (r *ProjectPathResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: passthroughStateUpgrader(),
 	}
 }
 
+var projectPathIdentity = envScopedIdentity(":", identityAttr{name: "id", description: "Project ID"})
+
+func (r *ProjectPathResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = projectPathIdentity.Schema()
+}
+
 func (r *ProjectPathResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData != nil {
 		if c, ok := req.ProviderData.(*sdkclient.Client); ok {
@@ -82,11 +123,20 @@ type projectPathModel struct {
 	Name            types.String `tfsdk:"name"`
 	ComposePath     types.String `tfsdk:"compose_path"`
 	EnvPath         types.String `tfsdk:"env_path"`
+	OverridePaths   types.List   `tfsdk:"compose_override_paths"`
+	TemplateVars    types.Map    `tfsdk:"template_vars"`
 	ContentHashMode types.Bool   `tfsdk:"content_hash_mode"`
 	Compose         types.String `tfsdk:"compose_content"`
 	Env             types.String `tfsdk:"env_content"`
 	ComposeHash     types.String `tfsdk:"compose_content_hash"`
 	EnvHash         types.String `tfsdk:"env_content_hash"`
+	IncludePaths    types.List   `tfsdk:"include_paths"`
+	IncludeHashes   types.Map    `tfsdk:"include_file_hashes"`
+	SourceDir       types.String `tfsdk:"source_dir"`
+	SourceInclude   types.List   `tfsdk:"source_include"`
+	SourceExclude   types.List   `tfsdk:"source_exclude"`
+	SourceHashes    types.Map    `tfsdk:"source_file_hashes"`
+	SourceTreeHash  types.String `tfsdk:"source_tree_hash"`
 	Running         types.Bool   `tfsdk:"running"`
 	PullOnUpdate    types.Bool   `tfsdk:"pull_on_update"`
 	Path            types.String `tfsdk:"path"`
@@ -97,6 +147,24 @@ type projectPathModel struct {
 	UpdatedAt       types.String `tfsdk:"updated_at"`
 	RemoveFiles     types.Bool   `tfsdk:"remove_files"`
 	RemoveVolumes   types.Bool   `tfsdk:"remove_volumes"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// ValidateConfig checks the file paths are usable before any file is read.
+func (r *ProjectPathResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var cfg projectPathModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &cfg)...)
+	if resp.Diagnostics.HasError() || cfg.ComposePath.IsUnknown() {
+		return
+	}
+	if strings.TrimSpace(cfg.ComposePath.ValueString()) == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("compose_path"), "invalid compose_path", "compose_path must not be empty")
+		return
+	}
+	if !cfg.EnvPath.IsNull() && !cfg.EnvPath.IsUnknown() && cfg.EnvPath.ValueString() == cfg.ComposePath.ValueString() {
+		resp.Diagnostics.AddAttributeError(path.Root("env_path"), "invalid env_path", "env_path must not point to the compose file")
+	}
 }
 
 // ModifyPlan loads compose/env file contents into computed attributes so file changes are detected during planning.
@@ -113,26 +181,70 @@ func (r *ProjectPathResource) ModifyPlan(ctx context.Context, req resource.Modif
 	if plan.ComposePath.IsUnknown() || plan.ComposePath.IsNull() {
 		return
 	}
+	// Unknown variables leave the rendered content unknown until apply.
+	if !elementsKnown(plan.TemplateVars.IsUnknown(), mapValues(plan.TemplateVars)) {
+		return
+	}
 
-	composeBytes, err := os.ReadFile(plan.ComposePath.ValueString())
+	composeContent, err := readProjectPathFile(ctx, plan, plan.ComposePath.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddAttributeError(path.Root("compose_path"), "read compose file failed", err.Error())
+		resp.Diagnostics.AddAttributeError(path.Root("compose_path"), fileErrorSummary(err, "compose"), err.Error())
+		return
+	}
+	composeBytes := []byte(composeContent)
+	if issues := validateCompose(string(composeBytes)); len(issues) > 0 {
+		for _, issue := range issues {
+			resp.Diagnostics.AddAttributeError(path.Root("compose_path"), "invalid compose file", plan.ComposePath.ValueString()+": "+issue.String())
+		}
 		return
 	}
-	// Compute and set content/hash depending on mode
+	if !plan.OverridePaths.IsNull() && !plan.OverridePaths.IsUnknown() {
+		overrides := listToStrings(ctx, plan.OverridePaths)
+		for i, p := range overrides {
+			b, err := readProjectPathFile(ctx, plan, p)
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("compose_override_paths").AtListIndex(i), fileErrorSummary(err, "compose override"), err.Error())
+				continue
+			}
+			for _, issue := range validateComposeOverride(b) {
+				resp.Diagnostics.AddAttributeError(path.Root("compose_override_paths").AtListIndex(i), "invalid compose override", p+": "+issue.String())
+			}
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		merged, err := readProjectPathCompose(ctx, plan)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("compose_override_paths"), "merge compose overrides failed", err.Error())
+			return
+		}
+		composeBytes = []byte(merged)
+	}
+	// Compute and set content/hash depending on mode. The attributes of the other
+	// mode are planned as null so references to them are known at plan time
+	// (e.g. compose_content is always null with content_hash_mode).
 	if plan.ContentHashMode.ValueBool() {
 		h := sha256.Sum256(composeBytes)
 		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("compose_content_hash"), hex.EncodeToString(h[:]))...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("compose_content"), types.StringNull())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("env_content"), types.StringNull())...)
 	} else {
 		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("compose_content"), string(composeBytes))...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("compose_content_hash"), types.StringNull())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("env_content_hash"), types.StringNull())...)
+	}
+	if plan.EnvPath.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("env_content"), types.StringNull())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("env_content_hash"), types.StringNull())...)
 	}
 
 	if !plan.EnvPath.IsNull() && !plan.EnvPath.IsUnknown() {
-		b, err := os.ReadFile(plan.EnvPath.ValueString())
+		content, err := readProjectPathFile(ctx, plan, plan.EnvPath.ValueString())
 		if err != nil {
-			resp.Diagnostics.AddAttributeError(path.Root("env_path"), "read env file failed", err.Error())
+			resp.Diagnostics.AddAttributeError(path.Root("env_path"), fileErrorSummary(err, "env"), err.Error())
 			return
 		}
+		b := []byte(content)
 		if plan.ContentHashMode.ValueBool() {
 			h := sha256.Sum256(b)
 			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("env_content_hash"), hex.EncodeToString(h[:]))...)
@@ -140,6 +252,58 @@ func (r *ProjectPathResource) ModifyPlan(ctx context.Context, req resource.Modif
 			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("env_content"), string(b))...)
 		}
 	}
+
+	if plan.IncludePaths.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("include_file_hashes"), types.MapNull(types.StringType))...)
+	} else if !plan.IncludePaths.IsUnknown() {
+		files, err := projectPathIncludeFiles(ctx, plan)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("include_paths"), "read include files failed", err.Error())
+			return
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("include_file_hashes"), hashFiles(files))...)
+	}
+
+	if plan.SourceDir.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("source_file_hashes"), types.MapNull(types.StringType))...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("source_tree_hash"), types.StringNull())...)
+	} else if !plan.SourceDir.IsUnknown() && elementsKnown(plan.SourceInclude.IsUnknown(), plan.SourceInclude.Elements()) &&
+		elementsKnown(plan.SourceExclude.IsUnknown(), plan.SourceExclude.Elements()) {
+		files, err := projectPathSourceFiles(ctx, plan)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("source_dir"), "read source directory failed", err.Error())
+			return
+		}
+		hashes := hashFiles(files)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("source_file_hashes"), hashes)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("source_tree_hash"), treeHash(hashes))...)
+	}
+}
+
+// readProjectPathCompose reads (and renders) compose_path and merges
+// compose_override_paths on top in order.
+func readProjectPathCompose(ctx context.Context, m projectPathModel) (string, error) {
+	base, err := readProjectPathFile(ctx, m, m.ComposePath.ValueString())
+	if err != nil {
+		return "", err
+	}
+	var overrides []string
+	for _, p := range listToStrings(ctx, m.OverridePaths) {
+		b, err := readProjectPathFile(ctx, m, p)
+		if err != nil {
+			return "", err
+		}
+		overrides = append(overrides, b)
+	}
+	return mergeComposeDocuments(base, overrides)
+}
+
+// projectPathIncludeFiles reads the files matched by include_paths; nil when unset.
+func projectPathIncludeFiles(ctx context.Context, m projectPathModel) (map[string]string, error) {
+	if m.IncludePaths.IsNull() || m.IncludePaths.IsUnknown() {
+		return nil, nil
+	}
+	return collectIncludeFiles(filepath.Dir(m.ComposePath.ValueString()), listToStrings(ctx, m.IncludePaths))
 }
 
 func (r *ProjectPathResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -148,26 +312,31 @@ func (r *ProjectPathResource) Create(ctx context.Context, req resource.CreateReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts.Create)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Always read from file for API body
 	// Use contents from plan only if set (e.g., by ModifyPlan); otherwise read from path
 	compose := plan.Compose.ValueString()
 	if compose == "" {
-		b, err := os.ReadFile(plan.ComposePath.ValueString())
+		b, err := readProjectPathCompose(ctx, plan)
 		if err != nil {
-			resp.Diagnostics.AddError("read compose file failed", err.Error())
+			resp.Diagnostics.AddError(fileErrorSummary(err, "compose"), err.Error())
 			return
 		}
-		compose = string(b)
+		compose = b
 	}
 	var envStr *string
 	if !plan.EnvPath.IsNull() && !plan.EnvPath.IsUnknown() {
-		b, err := os.ReadFile(plan.EnvPath.ValueString())
+		s, err := readProjectPathFile(ctx, plan, plan.EnvPath.ValueString())
 		if err != nil {
-			resp.Diagnostics.AddError("read env file failed", err.Error())
+			resp.Diagnostics.AddError(fileErrorSummary(err, "env"), err.Error())
 			return
 		}
-		s := string(b)
 		envStr = &s
 	} else if !plan.Env.IsNull() && !plan.Env.IsUnknown() && plan.Env.ValueString() != "" {
 		s := plan.Env.ValueString()
@@ -181,6 +350,22 @@ func (r *ProjectPathResource) Create(ctx context.Context, req resource.CreateReq
 		resp.Diagnostics.AddError("create project failed", err.Error())
 		return
 	}
+	includeFiles, err := projectPathIncludeFiles(ctx, plan)
+	if err == nil {
+		err = writeProjectIncludes(ctx, r.client, envID, out.ID, includeFiles, nil)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("write project include files failed", err.Error())
+		return
+	}
+	sourceFiles, err := projectPathSourceFiles(ctx, plan)
+	if err == nil {
+		err = syncProjectSource(ctx, r.client, envID, out.ID, sourceFiles, nil)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("upload source directory failed", err.Error())
+		return
+	}
 
 	// Optionally manage lifecycle
 	if !plan.Running.IsNull() && !plan.Running.IsUnknown() {
@@ -234,6 +419,7 @@ func (r *ProjectPathResource) Create(ctx context.Context, req resource.CreateReq
 	state.Running = plan.Running
 	state.PullOnUpdate = plan.PullOnUpdate
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(projectPathIdentity.Sync(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *ProjectPathResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -260,8 +446,35 @@ func (r *ProjectPathResource) Read(ctx context.Context, req resource.ReadRequest
 	state.RunningCount = types.Int64Value(int64(out.RunningCount))
 	// Leave created_at and updated_at unchanged to avoid plan inconsistency
 	// Retain Compose/Env from local files in state; do not overwrite from server
+	if !state.IncludeHashes.IsNull() {
+		remote := hashFiles(serverIncludeFiles(out))
+		hashes := map[string]string{}
+		for p := range mapFromStringMap(ctx, state.IncludeHashes) {
+			if h, ok := remote[p]; ok {
+				hashes[p] = h
+			}
+		}
+		includeHashes, diags := types.MapValueFrom(ctx, types.StringType, hashes)
+		resp.Diagnostics.Append(diags...)
+		state.IncludeHashes = includeHashes
+	}
+	// Source files are only visible on the server when compose includes them;
+	// refresh what can be seen and keep the rest.
+	if !state.SourceHashes.IsNull() {
+		hashes := mapFromStringMap(ctx, state.SourceHashes)
+		for p, h := range hashFiles(serverIncludeFiles(out)) {
+			if _, ok := hashes[p]; ok {
+				hashes[p] = h
+			}
+		}
+		sourceHashes, diags := types.MapValueFrom(ctx, types.StringType, hashes)
+		resp.Diagnostics.Append(diags...)
+		state.SourceHashes = sourceHashes
+		state.SourceTreeHash = types.StringValue(treeHash(hashes))
+	}
 	// Preserve configuration values: PullOnUpdate, Running, RemoveFiles, RemoveVolumes, etc.
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(projectPathIdentity.Sync(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *ProjectPathResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -272,30 +485,35 @@ func (r *ProjectPathResource) Update(ctx context.Context, req resource.UpdateReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts.Update)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	envID := state.EnvironmentID.ValueString()
 	projID := state.ID.ValueString()
 	compose := plan.Compose.ValueString()
 	if plan.ContentHashMode.ValueBool() || compose == "" {
 		// In hash mode or when content missing, read from path
-		b, err := os.ReadFile(plan.ComposePath.ValueString())
+		b, err := readProjectPathCompose(ctx, plan)
 		if err != nil {
-			resp.Diagnostics.AddError("read compose file failed", err.Error())
+			resp.Diagnostics.AddError(fileErrorSummary(err, "compose"), err.Error())
 			return
 		}
-		compose = string(b)
+		compose = b
 	}
 	var envStr *string
 	if !plan.Env.IsNull() && !plan.Env.IsUnknown() && plan.Env.ValueString() != "" {
 		s := plan.Env.ValueString()
 		envStr = &s
 	} else if !plan.EnvPath.IsNull() && !plan.EnvPath.IsUnknown() {
-		b, err := os.ReadFile(plan.EnvPath.ValueString())
+		s, err := readProjectPathFile(ctx, plan, plan.EnvPath.ValueString())
 		if err != nil {
-			resp.Diagnostics.AddError("read env file failed", err.Error())
+			resp.Diagnostics.AddError(fileErrorSummary(err, "env"), err.Error())
 			return
 		}
-		s := string(b)
 		envStr = &s
 	}
 
@@ -317,6 +535,44 @@ func (r *ProjectPathResource) Update(ctx context.Context, req resource.UpdateReq
 		resp.Diagnostics.AddError("update project failed", err.Error())
 		return
 	}
+	// Upload include files whose hash differs from state (new, changed or drifted)
+	// and remove the ones no longer matched by include_paths.
+	includeFiles, err := projectPathIncludeFiles(ctx, plan)
+	if err == nil {
+		known := mapFromStringMap(ctx, state.IncludeHashes)
+		dropped := droppedIncludes(includeFiles, known)
+		for p, h := range hashFiles(includeFiles) {
+			if known[p] == h {
+				delete(includeFiles, p)
+			}
+		}
+		err = writeProjectIncludes(ctx, r.client, envID, projID, includeFiles, nil)
+		if err == nil {
+			err = deleteProjectIncludes(ctx, r.client, envID, projID, dropped)
+		}
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("write project include files failed", err.Error())
+		return
+	}
+	state.IncludePaths = plan.IncludePaths
+	state.IncludeHashes = plan.IncludeHashes
+	// Unsetting source_dir stops managing its files without deleting them.
+	if !plan.SourceDir.IsNull() {
+		sourceFiles, err := projectPathSourceFiles(ctx, plan)
+		if err == nil {
+			err = syncProjectSource(ctx, r.client, envID, projID, sourceFiles, mapFromStringMap(ctx, state.SourceHashes))
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("sync source directory failed", err.Error())
+			return
+		}
+	}
+	state.SourceDir = plan.SourceDir
+	state.SourceInclude = plan.SourceInclude
+	state.SourceExclude = plan.SourceExclude
+	state.SourceHashes = plan.SourceHashes
+	state.SourceTreeHash = plan.SourceTreeHash
 
 	// Redeploy if compose/env changed and desired running is true/unspecified
 	changedContent := (body.ComposeContent != nil) || (body.EnvContent != nil)
@@ -371,6 +627,8 @@ func (r *ProjectPathResource) Update(ctx context.Context, req resource.UpdateReq
 			state.Env = types.StringNull()
 		}
 	} else {
+		state.ComposeHash = types.StringNull()
+		state.EnvHash = types.StringNull()
 		if compose != "" {
 			state.Compose = types.StringValue(compose)
 		}
@@ -381,6 +639,7 @@ func (r *ProjectPathResource) Update(ctx context.Context, req resource.UpdateReq
 		}
 	}
 	state.PullOnUpdate = plan.PullOnUpdate
+	state.Timeouts = plan.Timeouts
 	// Redeploy if compose/env changed and enabled (default true) and desired running true/unspecified
 	changedContent = (body.ComposeContent != nil) || (body.EnvContent != nil)
 	if changedContent {
@@ -426,6 +685,7 @@ func (r *ProjectPathResource) Update(ctx context.Context, req resource.UpdateReq
 		}
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(projectPathIdentity.Sync(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *ProjectPathResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -434,6 +694,12 @@ func (r *ProjectPathResource) Delete(ctx context.Context, req resource.DeleteReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, diags := withOperationTimeout(ctx, state.Timeouts.Delete)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	envID := state.EnvironmentID.ValueString()
 	projID := state.ID.ValueString()
 	opts := sdkclient.ProjectDestroyOptions{RemoveFiles: state.RemoveFiles.ValueBool(), RemoveVolumes: state.RemoveVolumes.ValueBool()}
@@ -446,12 +712,5 @@ func (r *ProjectPathResource) Delete(ctx context.Context, req resource.DeleteReq
 }
 
 func (r *ProjectPathResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// envID:projectID
-	parts := strings.SplitN(req.ID, ":", 2)
-	if len(parts) != 2 {
-		resp.Diagnostics.AddError("invalid import id", "expected env_id:project_id")
-		return
-	}
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment_id"), parts[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+	projectPathIdentity.ImportState(ctx, req, resp)
 }
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"terraform-provider-arcane/internal/sdkclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v3"
+)
+
+// projectDeployEvent is the event type the provider records for each deploy.
+const projectDeployEvent = "project.terraform.deploy"
+
+// Outcomes recorded for a revision.
+const (
+	revisionDeployed   = "deployed"
+	revisionFailed     = "failed"
+	revisionRolledBack = "rolled_back"
+	revisionUnhealthy  = "unhealthy"
+)
+
+// nextRevision returns the revision following m's, starting at 1.
+func nextRevision(m projectModel) types.Int64 {
+	if m.Revision.IsNull() || m.Revision.IsUnknown() {
+		return types.Int64Value(1)
+	}
+	return types.Int64Value(m.Revision.ValueInt64() + 1)
+}
+
+// composeHash hashes a compose document in canonical YAML form, so the same
+// content hashes alike however it was written.
+func composeHash(content string) string {
+	var doc any
+	if err := yaml.Unmarshal([]byte(content), &doc); err == nil {
+		if b, err := yaml.Marshal(doc); err == nil {
+			content = string(b)
+		}
+	}
+	return contentHash(content)
+}
+
+func contentHash(s string) string {
+	h := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(h[:])
+}
+
+// recordProjectRevision records a deploy of m as a server event. The deploy
+// itself has already happened, so callers report a failure as a warning.
+func recordProjectRevision(ctx context.Context, c *sdkclient.Client, m projectModel, compose string, env *string, outcome, detail string) error {
+	severity := "success"
+	switch outcome {
+	case revisionFailed, revisionRolledBack:
+		severity = "error"
+	case revisionUnhealthy:
+		severity = "warning"
+	}
+	envHash := ""
+	if env != nil {
+		envHash = contentHash(*env)
+	}
+	return c.CreateEvent(context.WithoutCancel(ctx), sdkclient.EventCreateRequest{
+		Type:          projectDeployEvent,
+		Severity:      severity,
+		Title:         fmt.Sprintf("Project %s revision %d %s", m.Name.ValueString(), m.Revision.ValueInt64(), outcome),
+		Description:   detail,
+		ResourceType:  "project",
+		ResourceID:    m.ID.ValueString(),
+		ResourceName:  m.Name.ValueString(),
+		EnvironmentID: m.EnvironmentID.ValueString(),
+		Metadata: map[string]any{
+			"revision":    m.Revision.ValueInt64(),
+			"deployedAt":  m.DeployedAt.ValueString(),
+			"composeHash": composeHash(compose),
+			"envHash":     envHash,
+			"outcome":     outcome,
+		},
+	})
+}
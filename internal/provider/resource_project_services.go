@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+	"sort"
+
+	"terraform-provider-arcane/internal/sdkclient"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// projectServiceType is the element type of the computed services list shared by
+// the arcane_project resource and data source.
+var projectServiceType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"name":          types.StringType,
+	"image":         types.StringType,
+	"image_digest":  types.StringType,
+	"container_ids": types.ListType{ElemType: types.StringType},
+	"state":         types.StringType,
+	"health":        types.StringType,
+	"ports":         types.ListType{ElemType: types.StringType},
+}}
+
+type projectServiceModel struct {
+	Name         types.String `tfsdk:"name"`
+	Image        types.String `tfsdk:"image"`
+	ImageDigest  types.String `tfsdk:"image_digest"`
+	ContainerIDs types.List   `tfsdk:"container_ids"`
+	State        types.String `tfsdk:"state"`
+	Health       types.String `tfsdk:"health"`
+	Ports        types.List   `tfsdk:"ports"`
+}
+
+const (
+	servicesDescription      = "Per-service runtime status, sorted by service name."
+	serviceHealthDescription = "Healthcheck status (healthy, unhealthy, starting); null when the service has no healthcheck."
+)
+
+func projectServicesResourceAttribute() resourceschema.Attribute {
+	return resourceschema.ListNestedAttribute{
+		Computed:    true,
+		Description: servicesDescription,
+		NestedObject: resourceschema.NestedAttributeObject{Attributes: map[string]resourceschema.Attribute{
+			"name":          resourceschema.StringAttribute{Computed: true, Description: "Compose service name"},
+			"image":         resourceschema.StringAttribute{Computed: true, Description: "Image reference"},
+			"image_digest":  resourceschema.StringAttribute{Computed: true, Description: "Resolved image digest, if reported"},
+			"container_ids": resourceschema.ListAttribute{Computed: true, ElementType: types.StringType, Description: "IDs of the service's containers"},
+			"state":         resourceschema.StringAttribute{Computed: true, Description: "Container state (running, exited, ...)"},
+			"health":        resourceschema.StringAttribute{Computed: true, Description: serviceHealthDescription},
+			"ports":         resourceschema.ListAttribute{Computed: true, ElementType: types.StringType, Description: "Published ports"},
+		}},
+	}
+}
+
+func projectServicesDataSourceAttribute() dsschema.Attribute {
+	return dsschema.ListNestedAttribute{
+		Computed:    true,
+		Description: servicesDescription,
+		NestedObject: dsschema.NestedAttributeObject{Attributes: map[string]dsschema.Attribute{
+			"name":          dsschema.StringAttribute{Computed: true, Description: "Compose service name"},
+			"image":         dsschema.StringAttribute{Computed: true, Description: "Image reference"},
+			"image_digest":  dsschema.StringAttribute{Computed: true, Description: "Resolved image digest, if reported"},
+			"container_ids": dsschema.ListAttribute{Computed: true, ElementType: types.StringType, Description: "IDs of the service's containers"},
+			"state":         dsschema.StringAttribute{Computed: true, Description: "Container state (running, exited, ...)"},
+			"health":        dsschema.StringAttribute{Computed: true, Description: serviceHealthDescription},
+			"ports":         dsschema.ListAttribute{Computed: true, ElementType: types.StringType, Description: "Published ports"},
+		}},
+	}
+}
+
+// projectServicesValue converts the runtime services of a project into the services list.
+func projectServicesValue(ctx context.Context, in []sdkclient.ProjectRuntimeService) (types.List, diag.Diagnostics) {
+	services := append([]sdkclient.ProjectRuntimeService(nil), in...)
+	sort.Slice(services, func(i, j int) bool { return services[i].Name < services[j].Name })
+
+	var diags diag.Diagnostics
+	out := make([]projectServiceModel, 0, len(services))
+	for _, s := range services {
+		ids := s.ContainerIDs
+		if len(ids) == 0 && s.ContainerID != "" {
+			ids = []string{s.ContainerID}
+		}
+		idList, d := types.ListValueFrom(ctx, types.StringType, nonNilStrings(ids))
+		diags.Append(d...)
+		ports, d := types.ListValueFrom(ctx, types.StringType, nonNilStrings(s.Ports))
+		diags.Append(d...)
+		m := projectServiceModel{
+			Name:         types.StringValue(s.Name),
+			Image:        types.StringValue(s.Image),
+			ImageDigest:  types.StringNull(),
+			ContainerIDs: idList,
+			State:        types.StringValue(s.Status),
+			Health:       types.StringNull(),
+			Ports:        ports,
+		}
+		if s.ImageDigest != "" {
+			m.ImageDigest = types.StringValue(s.ImageDigest)
+		}
+		if s.Health != nil && *s.Health != "" {
+			m.Health = types.StringValue(*s.Health)
+		}
+		out = append(out, m)
+	}
+	list, d := types.ListValueFrom(ctx, projectServiceType, out)
+	diags.Append(d...)
+	return list, diags
+}
+
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
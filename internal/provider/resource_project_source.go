@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"terraform-provider-arcane/internal/sdkclient"
+)
+
+// defaultSourceExclude is always skipped when uploading source_dir.
+var defaultSourceExclude = []string{".git", ".terraform"}
+
+// sourceGlob is a compiled include/exclude pattern. Patterns support *, ? and
+// ** (any number of directories). A pattern without a slash matches the base
+// name at any depth; otherwise it matches the path relative to source_dir.
+type sourceGlob struct {
+	re       *regexp.Regexp
+	baseName bool
+}
+
+func compileSourceGlob(pattern string) (sourceGlob, error) {
+	p := strings.TrimPrefix(filepath.ToSlash(pattern), "./")
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(p); i++ {
+		switch c := p[i]; c {
+		case '*':
+			if i+1 < len(p) && p[i+1] == '*' {
+				i++
+				if i+1 < len(p) && p[i+1] == '/' {
+					i++
+					b.WriteString("(?:.*/)?")
+				} else {
+					b.WriteString(".*")
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return sourceGlob{}, fmt.Errorf("pattern %q: %w", pattern, err)
+	}
+	return sourceGlob{re: re, baseName: !strings.Contains(p, "/")}, nil
+}
+
+func (g sourceGlob) match(rel string) bool {
+	if g.baseName {
+		return g.re.MatchString(path.Base(rel))
+	}
+	return g.re.MatchString(rel)
+}
+
+func compileSourceGlobs(patterns []string) ([]sourceGlob, error) {
+	globs := make([]sourceGlob, 0, len(patterns))
+	for _, p := range patterns {
+		g, err := compileSourceGlob(p)
+		if err != nil {
+			return nil, err
+		}
+		globs = append(globs, g)
+	}
+	return globs, nil
+}
+
+func matchAnyGlob(globs []sourceGlob, rel string) bool {
+	for _, g := range globs {
+		if g.match(rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// collectSourceDir reads every regular file below dir that matches an include
+// pattern (all files when include is empty) and no exclude pattern. An excluded
+// directory is not descended into. Paths in skip (relative, slash-separated)
+// are left out. Files must be UTF-8 text.
+func collectSourceDir(dir string, include, exclude []string, skip map[string]bool) (map[string]string, error) {
+	inc, err := compileSourceGlobs(include)
+	if err != nil {
+		return nil, err
+	}
+	exc, err := compileSourceGlobs(append(append([]string(nil), defaultSourceExclude...), exclude...))
+	if err != nil {
+		return nil, err
+	}
+	files := map[string]string{}
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == "." {
+			return nil
+		}
+		if matchAnyGlob(exc, rel) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || skip[rel] || (len(inc) > 0 && !matchAnyGlob(inc, rel)) {
+			return nil
+		}
+		b, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		if !utf8.Valid(b) {
+			return fmt.Errorf("%s is not a UTF-8 text file; exclude it with source_exclude", rel)
+		}
+		files[rel] = string(b)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// treeHash combines per-file hashes into one hash over the sorted paths, so
+// adding, removing, renaming or editing any file changes it.
+func treeHash(hashes map[string]string) string {
+	paths := make([]string, 0, len(hashes))
+	for p := range hashes {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	h := sha256.New()
+	for _, p := range paths {
+		fmt.Fprintf(h, "%s\x00%s\n", p, hashes[p])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// projectPathSourceFiles reads the files of source_dir, leaving out the
+// compose, override and env files, which are uploaded as project content.
+// It returns nil when source_dir is unset.
+func projectPathSourceFiles(ctx context.Context, m projectPathModel) (map[string]string, error) {
+	if m.SourceDir.IsNull() || m.SourceDir.IsUnknown() {
+		return nil, nil
+	}
+	dir := m.SourceDir.ValueString()
+	skip := map[string]bool{}
+	for _, p := range append([]string{m.ComposePath.ValueString(), m.EnvPath.ValueString()}, listToStrings(ctx, m.OverridePaths)...) {
+		if p == "" {
+			continue
+		}
+		if rel, err := filepath.Rel(dir, p); err == nil && checkIncludePath(rel) == nil {
+			skip[filepath.ToSlash(rel)] = true
+		}
+	}
+	return collectSourceDir(dir, listToStrings(ctx, m.SourceInclude), listToStrings(ctx, m.SourceExclude), skip)
+}
+
+// syncProjectSource uploads files whose hash differs from prior and removes
+// files listed in prior that no longer exist locally.
+func syncProjectSource(ctx context.Context, c *sdkclient.Client, envID, projID string, files map[string]string, prior map[string]string) error {
+	hashes := hashFiles(files)
+	changed := map[string]string{}
+	for p, content := range files {
+		if prior[p] != hashes[p] {
+			changed[p] = content
+		}
+	}
+	if err := writeProjectIncludes(ctx, c, envID, projID, changed, nil); err != nil {
+		return err
+	}
+	var removed []string
+	for p := range prior {
+		if _, ok := files[p]; !ok {
+			removed = append(removed, p)
+		}
+	}
+	sort.Strings(removed)
+	for _, p := range removed {
+		if err := c.DeleteProjectInclude(ctx, envID, projID, p); err != nil && !strings.Contains(strings.ToLower(err.Error()), "404") {
+			return fmt.Errorf("remove file %s: %w", p, err)
+		}
+	}
+	return nil
+}
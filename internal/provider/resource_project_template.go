@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// templateError is a Go template parse or execution error in a file; line is 1-based, 0 if unknown.
+type templateError struct {
+	file string
+	line int
+	msg  string
+}
+
+func (e *templateError) Error() string {
+	if e.line == 0 {
+		return e.file + ": " + e.msg
+	}
+	return e.file + ": line " + strconv.Itoa(e.line) + ": " + e.msg
+}
+
+var templateErrLine = regexp.MustCompile(`^template: file:(\d+)(?::\d+)?: (?:executing "file" at )?(.*)$`)
+
+// renderTemplate renders content from file as a Go template with vars as data
+// ({{ .name }}). Referencing a variable that is not set is an error.
+func renderTemplate(file, content string, vars map[string]string) (string, error) {
+	tmpl, err := template.New("file").Option("missingkey=error").Parse(content)
+	if err == nil {
+		var b strings.Builder
+		if err = tmpl.Execute(&b, vars); err == nil {
+			return b.String(), nil
+		}
+	}
+	if m := templateErrLine.FindStringSubmatch(err.Error()); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return "", &templateError{file: file, line: line, msg: m[2]}
+	}
+	return "", &templateError{file: file, msg: strings.TrimPrefix(err.Error(), "template: file: ")}
+}
+
+// readProjectPathFile reads a file of an arcane_project_path, rendering it with
+// template_vars when they are set.
+func readProjectPathFile(ctx context.Context, m projectPathModel, file string) (string, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	if m.TemplateVars.IsNull() || m.TemplateVars.IsUnknown() {
+		return string(b), nil
+	}
+	return renderTemplate(file, string(b), mapFromStringMap(ctx, m.TemplateVars))
+}
+
+// fileErrorSummary returns the diagnostic summary for an error from readProjectPathFile.
+func fileErrorSummary(err error, what string) string {
+	var te *templateError
+	if errors.As(err, &te) {
+		return fmt.Sprintf("render %s template failed", what)
+	}
+	return fmt.Sprintf("read %s file failed", what)
+}
@@ -16,6 +16,7 @@ import (
 
 var _ resource.Resource = &RegistryResource{}
 var _ resource.ResourceWithImportState = &RegistryResource{}
+var _ resource.ResourceWithUpgradeState = &RegistryResource{}
 
 type RegistryResource struct{ client *sdkclient.Client }
 
@@ -27,6 +28,7 @@ func (r *RegistryResource) Metadata(_ context.Context, req resource.MetadataRequ
 
 func (r *RegistryResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
     resp.Schema = resourceschema.Schema{
+        Version: 1,
         Attributes: map[string]resourceschema.Attribute{
             "id": resourceschema.StringAttribute{Computed: true, PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
             "url": resourceschema.StringAttribute{Required: true, Description: "Registry URL"},
@@ -43,6 +45,12 @@ func (r *RegistryResource) Schema(_ context.Context, _ resource.SchemaRequest, r
     }
 }
 
+func (r *RegistryResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+    return map[int64]resource.StateUpgrader{
+        0: passthroughStateUpgrader(),
+    }
+}
+
 func (r *RegistryResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
     if req.ProviderData != nil {
         if c, ok := req.ProviderData.(*sdkclient.Client); ok { r.client = c }
@@ -16,6 +16,7 @@ import (
 
 var _ resource.Resource = &SettingsResource{}
 var _ resource.ResourceWithImportState = &SettingsResource{}
+var _ resource.ResourceWithUpgradeState = &SettingsResource{}
 
 type SettingsResource struct {
 	client *sdkclient.Client
@@ -29,6 +30,7 @@ func (r *SettingsResource) Metadata(_ context.Context, req resource.MetadataRequ
 
 func (r *SettingsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = resourceschema.Schema{
+		Version: 1,
 		Attributes: map[string]resourceschema.Attribute{
 			"id": resourceschema.StringAttribute{
 				Computed:      true,
@@ -121,6 +123,12 @@ func (r *SettingsResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 	}
 }
 
+func (r *SettingsResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: passthroughStateUpgrader(),
+	}
+}
+
 func (r *SettingsResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData != nil {
 		if c, ok := req.ProviderData.(*sdkclient.Client); ok {
@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Resource schemas carry a version so state written by older releases can be
+// migrated in place instead of re-imported. Version 1 is the first versioned
+// layout for every resource; version 0 states differ from it only by attributes
+// added since (e.g. timeouts), so they are carried forward with
+// passthroughStateUpgrader. Structural changes bump the version and register a
+// dedicated upgrader that declares the prior schema.
+
+// passthroughStateUpgrader re-decodes prior state against the current schema.
+// Attributes missing from the prior state decode as null and attributes no
+// longer in the schema are dropped, which suits purely additive schema changes.
+func passthroughStateUpgrader() resource.StateUpgrader {
+	return resource.StateUpgrader{
+		StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+			opts := tfprotov6.UnmarshalOpts{ValueFromJSONOpts: tftypes.ValueFromJSONOpts{IgnoreUndefinedAttributes: true}}
+			raw, err := req.RawState.UnmarshalWithOpts(resp.State.Schema.Type().TerraformType(ctx), opts)
+			if err != nil {
+				resp.Diagnostics.AddError("upgrade resource state failed", err.Error())
+				return
+			}
+			resp.State.Raw = raw
+		},
+	}
+}
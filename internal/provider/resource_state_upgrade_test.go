@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// upgradeState runs the upgrader r registers for version over a raw JSON state.
+func upgradeState(t *testing.T, r resource.Resource, version int64, raw string) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+	var sr resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &sr)
+	u, ok := r.(resource.ResourceWithUpgradeState)
+	if !ok {
+		t.Fatalf("%T has no state upgraders", r)
+	}
+	upgrader, ok := u.UpgradeState(ctx)[version]
+	if !ok {
+		t.Fatalf("%T has no upgrader for version %d", r, version)
+	}
+	req := resource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: []byte(raw)}}
+	resp := resource.UpgradeStateResponse{State: tfsdk.State{Schema: sr.Schema}}
+	upgrader.StateUpgrader(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("upgrade %T from version %d: %v", r, version, resp.Diagnostics)
+	}
+	return resp.State
+}
+
+func TestPassthroughStateUpgrader(t *testing.T) {
+	// A v0 volume backup state predates the timeouts block and carries an
+	// attribute that is no longer in the schema.
+	state := upgradeState(t, NewVolumeBackupResource(), 0, `{
+		"id": "b1",
+		"environment_id": "env-1",
+		"volume_name": "data",
+		"size": 1024,
+		"removed_attribute": "x"
+	}`)
+	var m volumeBackupModel
+	if diags := state.Get(context.Background(), &m); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if m.ID.ValueString() != "b1" || m.VolumeName.ValueString() != "data" || m.Size.ValueInt64() != 1024 {
+		t.Errorf("attributes not carried forward: %+v", m)
+	}
+	if !m.Timeouts.Object.IsNull() {
+		t.Errorf("timeouts = %v, want null", m.Timeouts)
+	}
+}
+
+func TestEveryStateUpgraderAcceptsMinimalState(t *testing.T) {
+	ctx := context.Background()
+	for _, f := range (&ArcaneProvider{}).Resources(ctx) {
+		r := f()
+		u, ok := r.(resource.ResourceWithUpgradeState)
+		if !ok {
+			continue
+		}
+		for version := range u.UpgradeState(ctx) {
+			state := upgradeState(t, r, version, `{"id": "x"}`)
+			var id types.String
+			if diags := state.GetAttribute(ctx, path.Root("id"), &id); diags.HasError() {
+				t.Fatalf("%T v%d: %v", r, version, diags)
+			}
+			if id.ValueString() != "x" {
+				t.Errorf("%T v%d: id = %s, want x", r, version, id)
+			}
+		}
+	}
+}
+
+func TestContainerStateUpgradeMovesWiringIntoBlocks(t *testing.T) {
+	ctx := context.Background()
+	for _, version := range []int64{0, 1} {
+		state := upgradeState(t, NewContainerResource(), version, `{
+			"id": "c1",
+			"environment_id": "env-1",
+			"name": "web",
+			"image": "nginx:1.27",
+			"ports": {"80": "8080", "443/tcp": "8443"},
+			"volumes": ["/srv/site:/usr/share/nginx/html:ro", "cache:/var/cache/nginx", "/scratch"],
+			"networks": ["frontend"]
+		}`)
+		var m containerModel
+		if diags := state.Get(ctx, &m); diags.HasError() {
+			t.Fatalf("v%d: %v", version, diags)
+		}
+		if !m.Ports.IsNull() || !m.Volumes.IsNull() || !m.Networks.IsNull() {
+			t.Errorf("v%d: legacy attributes not cleared: %v %v %v", version, m.Ports, m.Volumes, m.Networks)
+		}
+
+		var ports []containerPortModel
+		m.PortBlocks.ElementsAs(ctx, &ports, false)
+		if len(ports) != 2 ||
+			ports[0].Internal.ValueInt64() != 80 || ports[0].External.ValueInt64() != 8080 ||
+			ports[1].Internal.ValueInt64() != 443 || ports[1].External.ValueInt64() != 8443 {
+			t.Errorf("v%d: ports = %+v", version, ports)
+		}
+
+		var mounts []containerMountModel
+		m.Mounts.ElementsAs(ctx, &mounts, false)
+		if len(mounts) != 3 {
+			t.Fatalf("v%d: mounts = %+v", version, mounts)
+		}
+		if mounts[0].Type.ValueString() != "bind" || mounts[0].Source.ValueString() != "/srv/site" ||
+			mounts[0].Target.ValueString() != "/usr/share/nginx/html" || !mounts[0].ReadOnly.ValueBool() {
+			t.Errorf("v%d: bind mount = %+v", version, mounts[0])
+		}
+		if mounts[1].Type.ValueString() != "volume" || mounts[1].Source.ValueString() != "cache" || !mounts[1].ReadOnly.IsNull() {
+			t.Errorf("v%d: volume mount = %+v", version, mounts[1])
+		}
+		if mounts[2].Type.ValueString() != "volume" || !mounts[2].Source.IsNull() || mounts[2].Target.ValueString() != "/scratch" {
+			t.Errorf("v%d: anonymous volume = %+v", version, mounts[2])
+		}
+
+		var networks []containerNetworkAttachmentModel
+		m.NetworkAttachments.ElementsAs(ctx, &networks, false)
+		if len(networks) != 1 || networks[0].Name.ValueString() != "frontend" {
+			t.Errorf("v%d: networks = %+v", version, networks)
+		}
+	}
+}
+
+func TestContainerStateUpgradeWithoutWiring(t *testing.T) {
+	state := upgradeState(t, NewContainerResource(), 1, `{"id": "c1", "environment_id": "env-1", "name": "web", "image": "nginx"}`)
+	var m containerModel
+	if diags := state.Get(context.Background(), &m); diags.HasError() {
+		t.Fatal(diags)
+	}
+	// Absent blocks plan as empty lists; null lists would force a replacement.
+	for name, l := range map[string]types.List{
+		"port": m.PortBlocks, "mount": m.Mounts, "network_attachment": m.NetworkAttachments,
+		"healthcheck": m.Healthcheck, "logging": m.Logging, "device": m.Devices, "ulimit": m.Ulimits,
+	} {
+		if l.IsNull() || len(l.Elements()) != 0 {
+			t.Errorf("%s = %v, want empty list", name, l)
+		}
+	}
+}
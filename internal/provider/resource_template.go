@@ -16,6 +16,7 @@ import (
 
 var _ resource.Resource = &TemplateResource{}
 var _ resource.ResourceWithImportState = &TemplateResource{}
+var _ resource.ResourceWithUpgradeState = &TemplateResource{}
 
 type TemplateResource struct {
 	client *sdkclient.Client
@@ -31,6 +32,7 @@ func (r *TemplateResource) Metadata(_ context.Context, req resource.MetadataRequ
 
 func (r *TemplateResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = resourceschema.Schema{
+		Version:     1,
 		Description: "Manages a reusable docker-compose template.",
 		Attributes: map[string]resourceschema.Attribute{
 			"id": resourceschema.StringAttribute{
@@ -72,6 +74,12 @@ func (r *TemplateResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 	}
 }
 
+func (r *TemplateResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: passthroughStateUpgrader(),
+	}
+}
+
 func (r *TemplateResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData != nil {
 		if c, ok := req.ProviderData.(*sdkclient.Client); ok {
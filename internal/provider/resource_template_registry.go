@@ -18,6 +18,7 @@ import (
 
 var _ resource.Resource = &TemplateRegistryResource{}
 var _ resource.ResourceWithImportState = &TemplateRegistryResource{}
+var _ resource.ResourceWithUpgradeState = &TemplateRegistryResource{}
 
 type TemplateRegistryResource struct {
 	client *sdkclient.Client
@@ -33,6 +34,7 @@ func (r *TemplateRegistryResource) Metadata(_ context.Context, req resource.Meta
 
 func (r *TemplateRegistryResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = resourceschema.Schema{
+		Version:     1,
 		Description: "Manages an external template registry for accessing remote templates.",
 		Attributes: map[string]resourceschema.Attribute{
 			"id": resourceschema.StringAttribute{
@@ -62,6 +64,12 @@ func (r *TemplateRegistryResource) Schema(_ context.Context, _ resource.SchemaRe
 	}
 }
 
+func (r *TemplateRegistryResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: passthroughStateUpgrader(),
+	}
+}
+
 func (r *TemplateRegistryResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData != nil {
 		if c, ok := req.ProviderData.(*sdkclient.Client); ok {
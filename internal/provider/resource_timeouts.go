@@ -0,0 +1,20 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// withOperationTimeout bounds ctx by a timeout from the resource's timeouts block,
+// e.g. withOperationTimeout(ctx, plan.Timeouts.Create). When no timeout is configured
+// ctx is returned unchanged and the provider http_timeout applies to each request.
+func withOperationTimeout(ctx context.Context, get func(context.Context, time.Duration) (time.Duration, diag.Diagnostics)) (context.Context, context.CancelFunc, diag.Diagnostics) {
+	d, diags := get(ctx, 0)
+	if diags.HasError() || d <= 0 {
+		return ctx, func() {}, diags
+	}
+	ctx, cancel := context.WithTimeout(ctx, d)
+	return ctx, cancel, diags
+}
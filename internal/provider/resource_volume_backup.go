@@ -6,7 +6,7 @@ import (
 
 	"terraform-provider-arcane/internal/sdkclient"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -16,6 +16,8 @@ import (
 
 var _ resource.Resource = &VolumeBackupResource{}
 var _ resource.ResourceWithImportState = &VolumeBackupResource{}
+var _ resource.ResourceWithIdentity = &VolumeBackupResource{}
+var _ resource.ResourceWithUpgradeState = &VolumeBackupResource{}
 
 type VolumeBackupResource struct{ client *sdkclient.Client }
 
@@ -25,8 +27,9 @@ func (r *VolumeBackupResource) Metadata(_ context.Context, req resource.Metadata
 	resp.TypeName = req.ProviderTypeName + "_volume_backup"
 }
 
-func (r *VolumeBackupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *VolumeBackupResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = resourceschema.Schema{
+		Version:     1,
 		Description: "Creates and manages snapshots (backups) for a Docker volume.",
 		Attributes: map[string]resourceschema.Attribute{
 			"id": resourceschema.StringAttribute{
@@ -61,9 +64,28 @@ func (r *VolumeBackupResource) Schema(_ context.Context, _ resource.SchemaReques
 				Description: "Last update timestamp",
 			},
 		},
+		Blocks: map[string]resourceschema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Delete: true}),
+		},
 	}
 }
 
+func (r *VolumeBackupResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: passthroughStateUpgrader(),
+	}
+}
+
+var volumeBackupIdentity = compositeIdentity{sep: "/", attrs: []identityAttr{
+	envIdentityAttr,
+	{name: "volume_name", description: "Volume name"},
+	{name: "id", description: "Backup ID"},
+}}
+
+func (r *VolumeBackupResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = volumeBackupIdentity.Schema()
+}
+
 func (r *VolumeBackupResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData != nil {
 		if c, ok := req.ProviderData.(*sdkclient.Client); ok {
@@ -79,6 +101,8 @@ type volumeBackupModel struct {
 	Size          types.Int64  `tfsdk:"size"`
 	CreatedAt     types.String `tfsdk:"created_at"`
 	UpdatedAt     types.String `tfsdk:"updated_at"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *VolumeBackupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -87,6 +111,12 @@ func (r *VolumeBackupResource) Create(ctx context.Context, req resource.CreateRe
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts.Create)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	out, err := r.client.CreateVolumeBackup(ctx, plan.EnvironmentID.ValueString(), plan.VolumeName.ValueString())
 	if err != nil {
@@ -106,6 +136,7 @@ func (r *VolumeBackupResource) Create(ctx context.Context, req resource.CreateRe
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(volumeBackupIdentity.Sync(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *VolumeBackupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -147,16 +178,20 @@ func (r *VolumeBackupResource) Read(ctx context.Context, req resource.ReadReques
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(volumeBackupIdentity.Sync(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *VolumeBackupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// All mutable fields are marked RequiresReplace.
-	var state volumeBackupModel
+	// All mutable fields are marked RequiresReplace; only the timeouts block can change in place.
+	var plan, state volumeBackupModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	state.Timeouts = plan.Timeouts
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(volumeBackupIdentity.Sync(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *VolumeBackupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -165,6 +200,12 @@ func (r *VolumeBackupResource) Delete(ctx context.Context, req resource.DeleteRe
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel, diags := withOperationTimeout(ctx, state.Timeouts.Delete)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	if err := r.client.DeleteVolumeBackup(ctx, state.EnvironmentID.ValueString(), state.ID.ValueString()); err != nil {
 		if strings.Contains(strings.ToLower(err.Error()), "404") {
@@ -175,14 +216,5 @@ func (r *VolumeBackupResource) Delete(ctx context.Context, req resource.DeleteRe
 }
 
 func (r *VolumeBackupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import format: environment_id/volume_name/backup_id
-	parts := strings.SplitN(req.ID, "/", 3)
-	if len(parts) != 3 {
-		resp.Diagnostics.AddError("invalid import ID", "Expected format: environment_id/volume_name/backup_id")
-		return
-	}
-
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment_id"), parts[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("volume_name"), parts[1])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[2])...)
+	volumeBackupIdentity.ImportState(ctx, req, resp)
 }
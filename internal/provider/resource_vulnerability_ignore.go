@@ -6,7 +6,6 @@ import (
 
 	"terraform-provider-arcane/internal/sdkclient"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -16,6 +15,8 @@ import (
 
 var _ resource.Resource = &VulnerabilityIgnoreResource{}
 var _ resource.ResourceWithImportState = &VulnerabilityIgnoreResource{}
+var _ resource.ResourceWithIdentity = &VulnerabilityIgnoreResource{}
+var _ resource.ResourceWithUpgradeState = &VulnerabilityIgnoreResource{}
 
 type VulnerabilityIgnoreResource struct{ client *sdkclient.Client }
 
@@ -27,6 +28,7 @@ func (r *VulnerabilityIgnoreResource) Metadata(_ context.Context, req resource.M
 
 func (r *VulnerabilityIgnoreResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = resourceschema.Schema{
+		Version: 1,
 		Attributes: map[string]resourceschema.Attribute{
 			"id": resourceschema.StringAttribute{
 				Computed:      true,
@@ -90,6 +92,18 @@ func (r *VulnerabilityIgnoreResource) Schema(_ context.Context, _ resource.Schem
 	}
 }
 
+func (r *VulnerabilityIgnoreResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: passthroughStateUpgrader(),
+	}
+}
+
+var vulnerabilityIgnoreIdentity = envScopedIdentity("/", identityAttr{name: "id", description: "Ignore record ID"})
+
+func (r *VulnerabilityIgnoreResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = vulnerabilityIgnoreIdentity.Schema()
+}
+
 func (r *VulnerabilityIgnoreResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData != nil {
 		if c, ok := req.ProviderData.(*sdkclient.Client); ok {
@@ -157,6 +171,7 @@ func (r *VulnerabilityIgnoreResource) Create(ctx context.Context, req resource.C
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(vulnerabilityIgnoreIdentity.Sync(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *VulnerabilityIgnoreResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -202,6 +217,7 @@ func (r *VulnerabilityIgnoreResource) Read(ctx context.Context, req resource.Rea
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(vulnerabilityIgnoreIdentity.Sync(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *VulnerabilityIgnoreResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -212,6 +228,7 @@ func (r *VulnerabilityIgnoreResource) Update(ctx context.Context, req resource.U
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(vulnerabilityIgnoreIdentity.Sync(ctx, resp.State, resp.Identity)...)
 }
 
 func (r *VulnerabilityIgnoreResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -230,13 +247,5 @@ func (r *VulnerabilityIgnoreResource) Delete(ctx context.Context, req resource.D
 }
 
 func (r *VulnerabilityIgnoreResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import format: environment_id/ignore_id
-	parts := strings.SplitN(req.ID, "/", 2)
-	if len(parts) != 2 {
-		resp.Diagnostics.AddError("invalid import ID", "Expected format: environment_id/ignore_id")
-		return
-	}
-
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment_id"), parts[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+	vulnerabilityIgnoreIdentity.ImportState(ctx, req, resp)
 }
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -19,6 +20,8 @@ type Client struct {
 	BaseURL *url.URL
 	APIKey  string
 	http    *http.Client
+	// timeout bounds each request whose context carries no deadline of its own.
+	timeout time.Duration
 }
 
 func NewClient(endpoint, apiKey string) *Client {
@@ -37,8 +40,8 @@ func NewClientWithOptions(endpoint, apiKey string, timeout time.Duration, insecu
 	return &Client{
 		BaseURL: u,
 		APIKey:  apiKey,
+		timeout: timeout,
 		http: &http.Client{
-			Timeout: timeout,
 			Transport: &http.Transport{
 				TLSClientConfig: &tls.Config{InsecureSkipVerify: insecure},
 			},
@@ -71,6 +74,13 @@ func (c *Client) newRequest(ctx context.Context, method, p string, body any) (*h
 }
 
 func (c *Client) do(req *http.Request, v any) error {
+	// Callers running long operations pass a context with their own deadline;
+	// everything else falls back to the per-request client timeout.
+	if _, ok := req.Context().Deadline(); !ok && c.timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), c.timeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
 	res, err := c.http.Do(req)
 	if err != nil {
 		return err
@@ -262,6 +272,22 @@ type ProjectDetails struct {
 		RelativePath string `json:"relativePath"`
 		Content      string `json:"content"`
 	} `json:"includeFiles,omitempty"`
+	RuntimeServices []ProjectRuntimeService `json:"runtimeServices,omitempty"`
+}
+
+// ProjectRuntimeService is the live state of one compose service of a project.
+// Health is nil when the service defines no healthcheck. ContainerIDs lists every
+// replica; older servers only report the single ContainerID.
+type ProjectRuntimeService struct {
+	Name          string   `json:"name"`
+	Image         string   `json:"image"`
+	ImageDigest   string   `json:"imageDigest,omitempty"`
+	Status        string   `json:"status"`
+	Health        *string  `json:"health,omitempty"`
+	ContainerID   string   `json:"containerId,omitempty"`
+	ContainerIDs  []string `json:"containerIds,omitempty"`
+	ContainerName string   `json:"containerName,omitempty"`
+	Ports         []string `json:"ports,omitempty"`
 }
 
 type projectDetailsEnvelope struct {
@@ -327,6 +353,114 @@ func (c *Client) DestroyProject(ctx context.Context, envID, projectID string, op
 	return c.do(req, nil)
 }
 
+// ProjectIncludeUpdateRequest writes one compose include file; RelativePath is
+// relative to the project directory.
+type ProjectIncludeUpdateRequest struct {
+	RelativePath string `json:"relativePath"`
+	Content      string `json:"content"`
+}
+
+// UpdateProjectInclude PUT /environments/{id}/projects/{projectId}/includes
+func (c *Client) UpdateProjectInclude(ctx context.Context, envID, projectID string, body ProjectIncludeUpdateRequest) error {
+	req, err := c.newRequest(ctx, http.MethodPut, path.Join("environments", envID, "projects", projectID, "includes"), body)
+	if err != nil {
+		return err
+	}
+	return c.do(req, nil)
+}
+
+// DeleteProjectInclude DELETE /environments/{id}/projects/{projectId}/includes?relativePath=...
+// removes a file from the project directory.
+func (c *Client) DeleteProjectInclude(ctx context.Context, envID, projectID, relativePath string) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, path.Join("environments", envID, "projects", projectID, "includes"), nil)
+	if err != nil {
+		return err
+	}
+	q := req.URL.Query()
+	q.Set("relativePath", relativePath)
+	req.URL.RawQuery = q.Encode()
+	return c.do(req, nil)
+}
+
+// -------- Events --------
+type Event struct {
+	ID            string         `json:"id"`
+	Type          string         `json:"type"`
+	Severity      string         `json:"severity"`
+	Title         string         `json:"title"`
+	Description   string         `json:"description,omitempty"`
+	ResourceType  string         `json:"resourceType,omitempty"`
+	ResourceID    string         `json:"resourceId,omitempty"`
+	ResourceName  string         `json:"resourceName,omitempty"`
+	EnvironmentID string         `json:"environmentId,omitempty"`
+	Metadata      map[string]any `json:"metadata,omitempty"`
+	Timestamp     string         `json:"timestamp"`
+}
+
+type EventCreateRequest struct {
+	Type          string         `json:"type"`
+	Severity      string         `json:"severity"`
+	Title         string         `json:"title"`
+	Description   string         `json:"description,omitempty"`
+	ResourceType  string         `json:"resourceType,omitempty"`
+	ResourceID    string         `json:"resourceId,omitempty"`
+	ResourceName  string         `json:"resourceName,omitempty"`
+	EnvironmentID string         `json:"environmentId,omitempty"`
+	Metadata      map[string]any `json:"metadata,omitempty"`
+}
+
+type eventListEnvelope struct {
+	Success    bool       `json:"success"`
+	Data       []Event    `json:"data"`
+	Pagination Pagination `json:"pagination"`
+}
+
+// eventPageSize is the number of events requested per page.
+const eventPageSize = 500
+
+// ListEnvironmentEvents GET /events/environment/{environmentId}, following the
+// pagination until the last page. A page that adds no new event also ends the
+// listing, so a server that ignores the page parameter is read only once.
+func (c *Client) ListEnvironmentEvents(ctx context.Context, envID string) ([]Event, error) {
+	var events []Event
+	seen := map[string]bool{}
+	for page := 1; ; page++ {
+		req, err := c.newRequest(ctx, http.MethodGet, path.Join("events", "environment", envID), nil)
+		if err != nil {
+			return nil, err
+		}
+		q := req.URL.Query()
+		q.Set("page", strconv.Itoa(page))
+		q.Set("limit", strconv.Itoa(eventPageSize))
+		req.URL.RawQuery = q.Encode()
+		var out eventListEnvelope
+		if err := c.do(req, &out); err != nil {
+			return nil, err
+		}
+		added := 0
+		for _, e := range out.Data {
+			if seen[e.ID] {
+				continue
+			}
+			seen[e.ID] = true
+			events = append(events, e)
+			added++
+		}
+		if added == 0 || int64(page) >= out.Pagination.TotalPages {
+			return events, nil
+		}
+	}
+}
+
+// CreateEvent POST /events
+func (c *Client) CreateEvent(ctx context.Context, body EventCreateRequest) error {
+	req, err := c.newRequest(ctx, http.MethodPost, "events", body)
+	if err != nil {
+		return err
+	}
+	return c.do(req, nil)
+}
+
 // -------- Notifications --------
 type NotificationUpdate struct {
 	Provider string         `json:"provider"`
@@ -400,6 +534,88 @@ type ContainerCreateRequest struct {
 	OpenStdin       *bool             `json:"openStdin,omitempty"`
 	StdinOnce       *bool             `json:"stdinOnce,omitempty"`
 	NetworkDisabled *bool             `json:"networkDisabled,omitempty"`
+
+	Healthcheck    *ContainerHealthcheck `json:"healthcheck,omitempty"`
+	LogConfig      *ContainerLogConfig   `json:"logConfig,omitempty"`
+	CapAdd         []string              `json:"capAdd,omitempty"`
+	CapDrop        []string              `json:"capDrop,omitempty"`
+	Devices        []ContainerDevice     `json:"devices,omitempty"`
+	Ulimits        []ContainerUlimit     `json:"ulimits,omitempty"`
+	ExtraHosts     []string              `json:"extraHosts,omitempty"`
+	DNS            []string              `json:"dns,omitempty"`
+	Sysctls        map[string]string     `json:"sysctls,omitempty"`
+	ShmSize        *int64                `json:"shmSize,omitempty"`
+	ReadonlyRootfs *bool                 `json:"readonlyRootfs,omitempty"`
+	SecurityOpt    []string              `json:"securityOpt,omitempty"`
+	Tmpfs          map[string]string     `json:"tmpfs,omitempty"`
+	Init           *bool                 `json:"init,omitempty"`
+
+	// Structured alternatives to Ports, Volumes and Networks.
+	ExposedPorts    map[string]struct{}                `json:"exposedPorts,omitempty"`
+	PortBindings    map[string][]ContainerPortBinding  `json:"portBindings,omitempty"`
+	Mounts          []ContainerMountSpec               `json:"mounts,omitempty"`
+	EndpointsConfig map[string]ContainerEndpointConfig `json:"endpointsConfig,omitempty"`
+}
+
+// ContainerMountSpec is a mount requested at create time, as in the Docker API.
+type ContainerMountSpec struct {
+	Type          string                  `json:"type"`
+	Source        string                  `json:"source,omitempty"`
+	Target        string                  `json:"target"`
+	ReadOnly      bool                    `json:"readOnly,omitempty"`
+	BindOptions   *ContainerBindOptions   `json:"bindOptions,omitempty"`
+	VolumeOptions *ContainerVolumeOptions `json:"volumeOptions,omitempty"`
+	TmpfsOptions  *ContainerTmpfsOptions  `json:"tmpfsOptions,omitempty"`
+}
+
+type ContainerBindOptions struct {
+	Propagation string `json:"propagation,omitempty"`
+}
+
+type ContainerVolumeOptions struct {
+	NoCopy  bool   `json:"noCopy,omitempty"`
+	Subpath string `json:"subpath,omitempty"`
+}
+
+type ContainerTmpfsOptions struct {
+	SizeBytes int64 `json:"sizeBytes,omitempty"`
+}
+
+type ContainerEndpointConfig struct {
+	Aliases    []string               `json:"aliases,omitempty"`
+	IPAMConfig *ContainerEndpointIPAM `json:"ipamConfig,omitempty"`
+}
+
+// ContainerEndpointIPAM holds the static addresses requested for a network attachment.
+type ContainerEndpointIPAM struct {
+	IPv4Address string `json:"ipv4Address,omitempty"`
+	IPv6Address string `json:"ipv6Address,omitempty"`
+}
+
+// ContainerHealthcheck durations are in nanoseconds, as in the Docker API.
+type ContainerHealthcheck struct {
+	Test        []string `json:"test,omitempty"`
+	Interval    int64    `json:"interval,omitempty"`
+	Timeout     int64    `json:"timeout,omitempty"`
+	StartPeriod int64    `json:"startPeriod,omitempty"`
+	Retries     int64    `json:"retries,omitempty"`
+}
+
+type ContainerLogConfig struct {
+	Type   string            `json:"type"`
+	Config map[string]string `json:"config,omitempty"`
+}
+
+type ContainerDevice struct {
+	PathOnHost        string `json:"pathOnHost"`
+	PathInContainer   string `json:"pathInContainer"`
+	CgroupPermissions string `json:"cgroupPermissions"`
+}
+
+type ContainerUlimit struct {
+	Name string `json:"name"`
+	Soft int64  `json:"soft"`
+	Hard int64  `json:"hard"`
 }
 
 type ContainerCreated struct {
@@ -415,12 +631,111 @@ type containerCreatedEnvelope struct {
 	Data    ContainerCreated `json:"data"`
 }
 
+// ContainerDetails is the inspect payload of a container.
 type ContainerDetails struct {
-	ID      string `json:"id"`
-	Name    string `json:"name"`
-	Image   string `json:"image"`
-	Created string `json:"created"`
-	Status  string `json:"status"`
+	ID              string                   `json:"id"`
+	Name            string                   `json:"name"`
+	Image           string                   `json:"image"`
+	ImageID         string                   `json:"imageId"`
+	Created         string                   `json:"created"`
+	Status          string                   `json:"status"`
+	State           ContainerState           `json:"state"`
+	Config          ContainerConfig          `json:"config"`
+	HostConfig      ContainerHostConfig      `json:"hostConfig"`
+	NetworkSettings ContainerNetworkSettings `json:"networkSettings"`
+	Mounts          []ContainerMount         `json:"mounts"`
+}
+
+type ContainerState struct {
+	Status     string `json:"status"`
+	Running    bool   `json:"running"`
+	Paused     bool   `json:"paused"`
+	Restarting bool   `json:"restarting"`
+	ExitCode   int64  `json:"exitCode"`
+	Error      string `json:"error"`
+	StartedAt  string `json:"startedAt"`
+	FinishedAt string `json:"finishedAt"`
+}
+
+// ContainerConfig is the container-level configuration, merged with the image defaults.
+type ContainerConfig struct {
+	Hostname        string                `json:"hostname"`
+	Domainname      string                `json:"domainname"`
+	User            string                `json:"user"`
+	AttachStdin     bool                  `json:"attachStdin"`
+	AttachStdout    bool                  `json:"attachStdout"`
+	AttachStderr    bool                  `json:"attachStderr"`
+	ExposedPorts    map[string]struct{}   `json:"exposedPorts"`
+	Tty             bool                  `json:"tty"`
+	OpenStdin       bool                  `json:"openStdin"`
+	StdinOnce       bool                  `json:"stdinOnce"`
+	Env             []string              `json:"env"`
+	Cmd             []string              `json:"cmd"`
+	Image           string                `json:"image"`
+	WorkingDir      string                `json:"workingDir"`
+	Entrypoint      []string              `json:"entrypoint"`
+	NetworkDisabled bool                  `json:"networkDisabled"`
+	Labels          map[string]string     `json:"labels"`
+	Healthcheck     *ContainerHealthcheck `json:"healthcheck"`
+}
+
+type ContainerHostConfig struct {
+	Binds          []string                          `json:"binds"`
+	NetworkMode    string                            `json:"networkMode"`
+	PortBindings   map[string][]ContainerPortBinding `json:"portBindings"`
+	RestartPolicy  ContainerRestartPolicy            `json:"restartPolicy"`
+	AutoRemove     bool                              `json:"autoRemove"`
+	Privileged     bool                              `json:"privileged"`
+	NanoCPUs       int64                             `json:"nanoCpus"`
+	Memory         int64                             `json:"memory"`
+	LogConfig      ContainerLogConfig                `json:"logConfig"`
+	CapAdd         []string                          `json:"capAdd"`
+	CapDrop        []string                          `json:"capDrop"`
+	Devices        []ContainerDevice                 `json:"devices"`
+	Ulimits        []ContainerUlimit                 `json:"ulimits"`
+	ExtraHosts     []string                          `json:"extraHosts"`
+	DNS            []string                          `json:"dns"`
+	Sysctls        map[string]string                 `json:"sysctls"`
+	ShmSize        int64                             `json:"shmSize"`
+	ReadonlyRootfs bool                              `json:"readonlyRootfs"`
+	SecurityOpt    []string                          `json:"securityOpt"`
+	Tmpfs          map[string]string                 `json:"tmpfs"`
+	Init           *bool                             `json:"init"`
+	Mounts         []ContainerMountSpec              `json:"mounts"`
+}
+
+type ContainerPortBinding struct {
+	HostIP   string `json:"hostIp"`
+	HostPort string `json:"hostPort"`
+}
+
+type ContainerRestartPolicy struct {
+	Name              string `json:"name"`
+	MaximumRetryCount int64  `json:"maximumRetryCount"`
+}
+
+type ContainerNetworkSettings struct {
+	Networks map[string]ContainerEndpoint `json:"networks"`
+}
+
+type ContainerEndpoint struct {
+	NetworkID         string                 `json:"networkId"`
+	Aliases           []string               `json:"aliases"`
+	IPAMConfig        *ContainerEndpointIPAM `json:"ipamConfig"`
+	IPAddress         string                 `json:"ipAddress"`
+	GlobalIPv6Address string                 `json:"globalIPv6Address"`
+	MacAddress        string                 `json:"macAddress"`
+}
+
+type ContainerMount struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+	Driver      string `json:"driver"`
+	Mode        string `json:"mode"`
+	RW          bool   `json:"rw"`
+	Propagation string `json:"propagation"`
 }
 
 type containerDetailsEnvelope struct {
@@ -474,6 +789,310 @@ func (c *Client) DeleteContainer(ctx context.Context, envID, containerID string,
 	return c.do(req, nil)
 }
 
+// ContainerUpdateRequest changes the settings Docker can update on a live
+// container; nil fields are left unchanged.
+type ContainerUpdateRequest struct {
+	RestartPolicy *string  `json:"restartPolicy,omitempty"`
+	CPUs          *float64 `json:"cpus,omitempty"`
+	Memory        *int64   `json:"memory,omitempty"`
+}
+
+// UpdateContainer POST /environments/{id}/containers/{containerId}/update
+func (c *Client) UpdateContainer(ctx context.Context, envID, containerID string, body ContainerUpdateRequest) error {
+	req, err := c.newRequest(ctx, http.MethodPost, path.Join("environments", envID, "containers", containerID, "update"), body)
+	if err != nil {
+		return err
+	}
+	return c.do(req, nil)
+}
+
+// RenameContainer POST /environments/{id}/containers/{containerId}/rename
+func (c *Client) RenameContainer(ctx context.Context, envID, containerID, name string) error {
+	body := map[string]string{"name": name}
+	req, err := c.newRequest(ctx, http.MethodPost, path.Join("environments", envID, "containers", containerID, "rename"), body)
+	if err != nil {
+		return err
+	}
+	return c.do(req, nil)
+}
+
+// StartContainer POST /environments/{id}/containers/{containerId}/start
+func (c *Client) StartContainer(ctx context.Context, envID, containerID string) error {
+	return c.containerAction(ctx, envID, containerID, "start")
+}
+
+// StopContainer POST /environments/{id}/containers/{containerId}/stop
+func (c *Client) StopContainer(ctx context.Context, envID, containerID string) error {
+	return c.containerAction(ctx, envID, containerID, "stop")
+}
+
+// PauseContainer POST /environments/{id}/containers/{containerId}/pause
+func (c *Client) PauseContainer(ctx context.Context, envID, containerID string) error {
+	return c.containerAction(ctx, envID, containerID, "pause")
+}
+
+// UnpauseContainer POST /environments/{id}/containers/{containerId}/unpause
+func (c *Client) UnpauseContainer(ctx context.Context, envID, containerID string) error {
+	return c.containerAction(ctx, envID, containerID, "unpause")
+}
+
+func (c *Client) containerAction(ctx context.Context, envID, containerID, action string) error {
+	req, err := c.newRequest(ctx, http.MethodPost, path.Join("environments", envID, "containers", containerID, action), nil)
+	if err != nil {
+		return err
+	}
+	return c.do(req, nil)
+}
+
+// ContainerLogsOptions selects which log lines GetContainerLogs returns.
+// Tail 0 returns every line. Since is an RFC3339 time or Unix timestamp; empty
+// means from the start. Timestamps asks the server to fill in each line's Timestamp.
+type ContainerLogsOptions struct {
+	Tail       int64
+	Since      string
+	Timestamps bool
+	Stdout     bool
+	Stderr     bool
+}
+
+// ContainerLogLine is one line of container output.
+type ContainerLogLine struct {
+	Stream    string `json:"stream"`
+	Timestamp string `json:"timestamp,omitempty"`
+	Message   string `json:"message"`
+}
+
+type containerLogsEnvelope struct {
+	Success bool               `json:"success"`
+	Data    []ContainerLogLine `json:"data"`
+}
+
+// GetContainerLogs GET /environments/{id}/containers/{containerId}/logs
+func (c *Client) GetContainerLogs(ctx context.Context, envID, containerID string, opts ContainerLogsOptions) ([]ContainerLogLine, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, path.Join("environments", envID, "containers", containerID, "logs"), nil)
+	if err != nil {
+		return nil, err
+	}
+	q := req.URL.Query()
+	if opts.Tail > 0 {
+		q.Set("tail", strconv.FormatInt(opts.Tail, 10))
+	}
+	if opts.Since != "" {
+		q.Set("since", opts.Since)
+	}
+	if opts.Timestamps {
+		q.Set("timestamps", "true")
+	}
+	q.Set("stdout", strconv.FormatBool(opts.Stdout))
+	q.Set("stderr", strconv.FormatBool(opts.Stderr))
+	req.URL.RawQuery = q.Encode()
+	var out containerLogsEnvelope
+	if err := c.do(req, &out); err != nil {
+		return nil, err
+	}
+	return out.Data, nil
+}
+
+// ContainerStats is one Docker stats sample. Counters are cumulative since the
+// container started; PreCPUStats is the previous CPU sample, used to derive
+// CPU usage over the sampling interval.
+type ContainerStats struct {
+	Read        string                           `json:"read"`
+	PreRead     string                           `json:"preread"`
+	ID          string                           `json:"id"`
+	Name        string                           `json:"name"`
+	CPUStats    ContainerCPUStats                `json:"cpu_stats"`
+	PreCPUStats ContainerCPUStats                `json:"precpu_stats"`
+	MemoryStats ContainerMemoryStats             `json:"memory_stats"`
+	Networks    map[string]ContainerNetworkStats `json:"networks,omitempty"`
+	BlkioStats  ContainerBlkioStats              `json:"blkio_stats"`
+	PidsStats   ContainerPidsStats               `json:"pids_stats"`
+}
+
+type ContainerCPUStats struct {
+	CPUUsage struct {
+		TotalUsage  uint64   `json:"total_usage"`
+		PercpuUsage []uint64 `json:"percpu_usage,omitempty"`
+	} `json:"cpu_usage"`
+	SystemUsage uint64 `json:"system_cpu_usage"`
+	OnlineCPUs  uint32 `json:"online_cpus"`
+}
+
+// ContainerMemoryStats.Stats holds the raw cgroup counters, e.g. cache (cgroup v1)
+// or inactive_file (cgroup v2).
+type ContainerMemoryStats struct {
+	Usage    uint64            `json:"usage"`
+	MaxUsage uint64            `json:"max_usage,omitempty"`
+	Limit    uint64            `json:"limit"`
+	Stats    map[string]uint64 `json:"stats,omitempty"`
+}
+
+type ContainerNetworkStats struct {
+	RxBytes   uint64 `json:"rx_bytes"`
+	RxPackets uint64 `json:"rx_packets"`
+	RxErrors  uint64 `json:"rx_errors"`
+	RxDropped uint64 `json:"rx_dropped"`
+	TxBytes   uint64 `json:"tx_bytes"`
+	TxPackets uint64 `json:"tx_packets"`
+	TxErrors  uint64 `json:"tx_errors"`
+	TxDropped uint64 `json:"tx_dropped"`
+}
+
+type ContainerBlkioStats struct {
+	IoServiceBytesRecursive []ContainerBlkioEntry `json:"io_service_bytes_recursive,omitempty"`
+}
+
+// ContainerBlkioEntry.Op is e.g. "read" or "write"; servers differ in case.
+type ContainerBlkioEntry struct {
+	Major uint64 `json:"major"`
+	Minor uint64 `json:"minor"`
+	Op    string `json:"op"`
+	Value uint64 `json:"value"`
+}
+
+type ContainerPidsStats struct {
+	Current uint64 `json:"current,omitempty"`
+	Limit   uint64 `json:"limit,omitempty"`
+}
+
+type containerStatsEnvelope struct {
+	Success bool           `json:"success"`
+	Data    ContainerStats `json:"data"`
+}
+
+// GetContainerStats GET /environments/{id}/containers/{containerId}/stats
+// returns a single sample; the server waits for a second CPU sample before answering.
+func (c *Client) GetContainerStats(ctx context.Context, envID, containerID string) (*ContainerStats, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, path.Join("environments", envID, "containers", containerID, "stats"), nil)
+	if err != nil {
+		return nil, err
+	}
+	q := req.URL.Query()
+	q.Set("stream", "false")
+	req.URL.RawQuery = q.Encode()
+	var out containerStatsEnvelope
+	if err := c.do(req, &out); err != nil {
+		return nil, err
+	}
+	return &out.Data, nil
+}
+
+// ExecCreateRequest describes a command to run in a running container.
+type ExecCreateRequest struct {
+	Cmd          []string `json:"cmd"`
+	User         string   `json:"user,omitempty"`
+	WorkingDir   string   `json:"workingDir,omitempty"`
+	Env          []string `json:"env,omitempty"`
+	AttachStdout bool     `json:"attachStdout"`
+	AttachStderr bool     `json:"attachStderr"`
+}
+
+type execCreatedEnvelope struct {
+	Success bool `json:"success"`
+	Data    struct {
+		ID string `json:"id"`
+	} `json:"data"`
+}
+
+// ExecOutput is the demultiplexed output of a finished exec.
+type ExecOutput struct {
+	Stdout string `json:"stdout"`
+	Stderr string `json:"stderr"`
+}
+
+type execOutputEnvelope struct {
+	Success bool       `json:"success"`
+	Data    ExecOutput `json:"data"`
+}
+
+type ExecInspect struct {
+	ID       string `json:"id"`
+	Running  bool   `json:"running"`
+	ExitCode int64  `json:"exitCode"`
+	Pid      int64  `json:"pid"`
+}
+
+type execInspectEnvelope struct {
+	Success bool        `json:"success"`
+	Data    ExecInspect `json:"data"`
+}
+
+// ExecResult is the outcome of RunExec.
+type ExecResult struct {
+	ID       string
+	Stdout   string
+	Stderr   string
+	ExitCode int64
+}
+
+// CreateExec POST /environments/{id}/containers/{containerId}/exec returns the exec ID.
+func (c *Client) CreateExec(ctx context.Context, envID, containerID string, body ExecCreateRequest) (string, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, path.Join("environments", envID, "containers", containerID, "exec"), body)
+	if err != nil {
+		return "", err
+	}
+	var out execCreatedEnvelope
+	if err := c.do(req, &out); err != nil {
+		return "", err
+	}
+	return out.Data.ID, nil
+}
+
+// StartExec POST /environments/{id}/exec/{execId}/start runs the exec and
+// returns its output once the command has finished.
+func (c *Client) StartExec(ctx context.Context, envID, execID string) (*ExecOutput, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, path.Join("environments", envID, "exec", execID, "start"), nil)
+	if err != nil {
+		return nil, err
+	}
+	var out execOutputEnvelope
+	if err := c.do(req, &out); err != nil {
+		return nil, err
+	}
+	return &out.Data, nil
+}
+
+// InspectExec GET /environments/{id}/exec/{execId}
+func (c *Client) InspectExec(ctx context.Context, envID, execID string) (*ExecInspect, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, path.Join("environments", envID, "exec", execID), nil)
+	if err != nil {
+		return nil, err
+	}
+	var out execInspectEnvelope
+	if err := c.do(req, &out); err != nil {
+		return nil, err
+	}
+	return &out.Data, nil
+}
+
+// RunExec creates and starts an exec, then waits for its exit code. Docker
+// can report the exec as running briefly after its output ends, so the exit
+// code is polled until ctx is done.
+func (c *Client) RunExec(ctx context.Context, envID, containerID string, body ExecCreateRequest) (*ExecResult, error) {
+	id, err := c.CreateExec(ctx, envID, containerID, body)
+	if err != nil {
+		return nil, err
+	}
+	out, err := c.StartExec(ctx, envID, id)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		ins, err := c.InspectExec(ctx, envID, id)
+		if err != nil {
+			return nil, err
+		}
+		if !ins.Running {
+			return &ExecResult{ID: id, Stdout: out.Stdout, Stderr: out.Stderr, ExitCode: ins.ExitCode}, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
 // -------- Container Registries --------
 type CreateContainerRegistryRequest struct {
 	URL         string  `json:"url"`
@@ -1551,6 +2170,17 @@ type ImageDetail struct {
 	Architecture string   `json:"architecture"`
 	OS           string   `json:"os"`
 	Size         int64    `json:"size"`
+	// Config holds the defaults a container created from the image inherits.
+	Config *ImageConfig `json:"config,omitempty"`
+}
+
+type ImageConfig struct {
+	User       string            `json:"user"`
+	Env        []string          `json:"env"`
+	Cmd        []string          `json:"cmd"`
+	Entrypoint []string          `json:"entrypoint"`
+	WorkingDir string            `json:"workingDir"`
+	Labels     map[string]string `json:"labels"`
 }
 
 type imageDetailEnvelope struct {
@@ -1570,6 +2200,40 @@ func (c *Client) GetImage(ctx context.Context, envID, imageID string) (*ImageDet
 	return &out.Data, nil
 }
 
+// ImageUpdateInfo is the result of comparing a local image with its registry.
+type ImageUpdateInfo struct {
+	HasUpdate      bool   `json:"hasUpdate"`
+	UpdateType     string `json:"updateType"`
+	CurrentVersion string `json:"currentVersion"`
+	LatestVersion  string `json:"latestVersion"`
+	CurrentDigest  string `json:"currentDigest"`
+	LatestDigest   string `json:"latestDigest"`
+	CheckTime      string `json:"checkTime"`
+	Error          string `json:"error"`
+}
+
+type imageUpdateEnvelope struct {
+	Success bool            `json:"success"`
+	Data    ImageUpdateInfo `json:"data"`
+}
+
+// CheckImageUpdate GET /environments/{id}/image-updates/check?imageRef=...
+// resolves imageRef against its registry.
+func (c *Client) CheckImageUpdate(ctx context.Context, envID, imageRef string) (*ImageUpdateInfo, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, path.Join("environments", envID, "image-updates", "check"), nil)
+	if err != nil {
+		return nil, err
+	}
+	q := req.URL.Query()
+	q.Set("imageRef", imageRef)
+	req.URL.RawQuery = q.Encode()
+	var out imageUpdateEnvelope
+	if err := c.do(req, &out); err != nil {
+		return nil, err
+	}
+	return &out.Data, nil
+}
+
 type JobStatus struct {
 	ID             string `json:"id"`
 	Name           string `json:"name"`